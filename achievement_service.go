@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+// earnedAchievement is what a rule hands to Award. Code names the rule and Key
+// makes the achievement unique, so a rule can safely report it on every tick.
+type earnedAchievement struct {
+	Code        string
+	Key         string
+	Title       string
+	Description string
+	Payload     map[string]interface{}
+}
+
+// achievementRule inspects the user's history as of now and returns what they earned.
+// totals are the user's dailyTotals, loaded once per tick and shared by the rules.
+type achievementRule func(ctx context.Context, s *AchievementService, user database.User, now time.Time, totals map[string]int64) ([]earnedAchievement, error)
+
+var (
+	streakMilestones         = []int{3, 7, 14, 30, 60, 100, 365}
+	completedTasksMilestones = []int64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+)
+
+// personalBestMinDays is how many tracked days are needed before a best day counts
+const personalBestMinDays = 7
+
+type AchievementService struct {
+	queries   *database.Queries
+	notify    func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	broadcast func(userID uuid.UUID, event string, data interface{})
+	rules     []achievementRule
+}
+
+func NewAchievementService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), broadcast func(userID uuid.UUID, event string, data interface{})) *AchievementService {
+	return &AchievementService{
+		queries:   queries,
+		notify:    notify,
+		broadcast: broadcast,
+		rules: []achievementRule{
+			streakRule,
+			personalBestDayRule,
+			scheduleWeekRule,
+			completedTasksRule,
+		},
+	}
+}
+
+func (s *AchievementService) Tick(ctx context.Context) error {
+	users, err := s.queries.GetAllUsers(ctx)
+	if err != nil {
+		log.Printf("AchievementService: Failed to load users: %v", err)
+		return err
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		totals, err := s.dailyTotals(ctx, user, now)
+		if err != nil {
+			log.Printf("AchievementService: Failed to load daily totals for user %s: %v", user.ID, err)
+			// Continue with other users
+			continue
+		}
+
+		for _, rule := range s.rules {
+			earned, err := rule(ctx, s, user, now, totals)
+			if err != nil {
+				log.Printf("AchievementService: Rule failed for user %s: %v", user.ID, err)
+				// Continue with other rules
+				continue
+			}
+			for _, achievement := range earned {
+				if _, err := s.Award(ctx, user.ID, achievement); err != nil {
+					log.Printf("AchievementService: Failed to award %s for user %s: %v", achievement.Code, user.ID, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Award stores the achievement and announces it. It returns false without
+// notifying when the user already earned it.
+func (s *AchievementService) Award(ctx context.Context, userID uuid.UUID, earned earnedAchievement) (bool, error) {
+	details, err := json.Marshal(earned.Payload)
+	if err != nil {
+		return false, err
+	}
+
+	achievement, err := s.queries.CreateAchievement(ctx, database.CreateAchievementParams{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Code:        earned.Code,
+		Key:         earned.Key,
+		Title:       earned.Title,
+		Description: earned.Description,
+		Payload:     details,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("AchievementService: User %s earned %s (%s)", userID, achievement.Code, achievement.Key)
+
+	if s.broadcast != nil {
+		s.broadcast(userID, "achievement_earned", achievement)
+	}
+
+	if s.notify == nil {
+		return true, nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":           "achievement",
+		"achievement_id": achievement.ID,
+		"code":           achievement.Code,
+		"details":        achievement.Payload,
+	})
+	if err != nil {
+		return true, err
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           userID,
+		Title:            achievement.Title,
+		Description:      sql.NullString{String: achievement.Description, Valid: true},
+		Status:           "unseen",
+		NotificationType: "achievement",
+		Payload:          payload,
+		Priority:         "normal",
+		ExpiresAt:        sql.NullTime{Time: time.Now().Add(7 * 24 * time.Hour), Valid: true},
+		LastModifiedAt:   time.Now().UnixMilli(),
+	})
+	return true, err
+}
+
+// dailyTotals returns tracked seconds per user day over the last year, keyed by
+// the day's start in the user's timezone formatted as YYYY-MM-DD
+func (s *AchievementService) dailyTotals(ctx context.Context, user database.User, now time.Time) (map[string]int64, error) {
+	_, todayEnd := userDayBounds(user, now)
+	loc := userLocation(user)
+
+	rows, err := s.queries.GetTimeReport(ctx, database.GetTimeReportParams{
+		UserID:       user.ID,
+		Period:       "day",
+		Timezone:     loc.String(),
+		RolloverHour: user.RolloverHour,
+		GroupBy:      "category",
+		StartAt:      todayEnd.AddDate(-1, 0, -1),
+		EndAt:        todayEnd.Add(time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64)
+	for _, row := range rows {
+		totals[row.PeriodStart.Format("2006-01-02")] += row.TotalSeconds
+	}
+	return totals, nil
+}
+
+// currentStreak counts consecutive tracked days ending with the day containing
+// from, and returns the local start of the first one
+func currentStreak(user database.User, totals map[string]int64, from time.Time) (int, time.Time) {
+	dayStart, _ := userDayBounds(user, from)
+	day := dayStart.In(userLocation(user))
+
+	streak := 0
+	first := day
+	for totals[day.Format("2006-01-02")] > 0 {
+		streak++
+		first = day
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak, first
+}
+
+// CurrentStreak is the user's streak including today when something was tracked today
+func (s *AchievementService) CurrentStreak(ctx context.Context, user database.User) (int, error) {
+	now := time.Now()
+	totals, err := s.dailyTotals(ctx, user, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if streak, _ := currentStreak(user, totals, now); streak > 0 {
+		return streak, nil
+	}
+	streak, _ := currentStreak(user, totals, now.AddDate(0, 0, -1))
+	return streak, nil
+}
+
+// streakRule awards the highest milestone reached by the streak ending yesterday
+func streakRule(ctx context.Context, s *AchievementService, user database.User, now time.Time, totals map[string]int64) ([]earnedAchievement, error) {
+	streak, first := currentStreak(user, totals, now.AddDate(0, 0, -1))
+	milestone := 0
+	for _, m := range streakMilestones {
+		if streak >= m {
+			milestone = m
+		}
+	}
+	if milestone == 0 {
+		return nil, nil
+	}
+
+	return []earnedAchievement{{
+		Code:        "streak",
+		Key:         fmt.Sprintf("%d:%s", milestone, first.Format("2006-01-02")),
+		Title:       fmt.Sprintf("%d Day Streak", milestone),
+		Description: fmt.Sprintf("You tracked time %d days in a row.", milestone),
+		Payload: map[string]interface{}{
+			"days":       milestone,
+			"started_on": first.Format("2006-01-02"),
+		},
+	}}, nil
+}
+
+// personalBestDayRule awards yesterday when it beat every earlier day of the last year
+func personalBestDayRule(ctx context.Context, s *AchievementService, user database.User, now time.Time, totals map[string]int64) ([]earnedAchievement, error) {
+	yesterdayStart, _ := userDayBounds(user, now.AddDate(0, 0, -1))
+	yesterday := yesterdayStart.In(userLocation(user)).Format("2006-01-02")
+	best := totals[yesterday]
+	if best == 0 {
+		return nil, nil
+	}
+
+	previousDays := 0
+	for day, total := range totals {
+		if day >= yesterday || total == 0 {
+			continue
+		}
+		if total >= best {
+			return nil, nil
+		}
+		previousDays++
+	}
+	if previousDays < personalBestMinDays {
+		return nil, nil
+	}
+
+	duration, err := durationIntToStr(best)
+	if err != nil {
+		return nil, err
+	}
+
+	return []earnedAchievement{{
+		Code:        "personal_best_day",
+		Key:         yesterday,
+		Title:       "Personal Best Day",
+		Description: fmt.Sprintf("You tracked %s on %s, your best day yet.", duration, yesterday),
+		Payload: map[string]interface{}{
+			"day":           yesterday,
+			"total_seconds": best,
+		},
+	}}, nil
+}
+
+// scheduleWeekRule awards recurring task schedules whose every occurrence last week was completed
+func scheduleWeekRule(ctx context.Context, s *AchievementService, user database.User, now time.Time, totals map[string]int64) ([]earnedAchievement, error) {
+	weekStart, _ := userPeriodBounds(user, "week", now)
+	previousStart, previousEnd := userPeriodBounds(user, "week", weekStart.Add(-time.Second))
+
+	rows, err := s.queries.GetScheduleCompletion(ctx, database.GetScheduleCompletionParams{
+		UserID:  user.ID,
+		StartAt: previousStart,
+		EndAt:   previousEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	week := previousStart.In(userLocation(user)).Format("2006-01-02")
+	var earned []earnedAchievement
+	for _, row := range rows {
+		if row.Occurrences == 0 || row.Completed < row.Occurrences {
+			continue
+		}
+		earned = append(earned, earnedAchievement{
+			Code:        "schedule_week",
+			Key:         fmt.Sprintf("%s:%s", row.ScheduleID, week),
+			Title:       "Perfect Week",
+			Description: fmt.Sprintf("You completed all %d occurrences of '%s' last week.", row.Occurrences, row.Title),
+			Payload: map[string]interface{}{
+				"schedule_id": row.ScheduleID,
+				"title":       row.Title,
+				"week_start":  week,
+				"occurrences": row.Occurrences,
+			},
+		})
+	}
+	return earned, nil
+}
+
+// completedTasksRule awards the highest completed-task milestone reached. Tasks
+// completed by a clone rollover are not counted, only the copy that was finished.
+func completedTasksRule(ctx context.Context, s *AchievementService, user database.User, now time.Time, totals map[string]int64) ([]earnedAchievement, error) {
+	completed, err := s.queries.CountCompletedTasksByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var milestone int64
+	for _, m := range completedTasksMilestones {
+		if completed >= m {
+			milestone = m
+		}
+	}
+	if milestone == 0 {
+		return nil, nil
+	}
+
+	return []earnedAchievement{{
+		Code:        "tasks_completed",
+		Key:         fmt.Sprintf("%d", milestone),
+		Title:       fmt.Sprintf("%d Tasks Completed", milestone),
+		Description: fmt.Sprintf("You have completed %d tasks.", milestone),
+		Payload: map[string]interface{}{
+			"count": milestone,
+		},
+	}}, nil
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	budgetWarningPercent = 75
+	// Remaining time is pushed every minute once a budget is this close, otherwise every few minutes
+	budgetCloseSeconds    = 15 * 60
+	budgetPushEveryMinute = 5
+)
+
+type budgetStatus struct {
+	BudgetMinutes    int32 `json:"budget_minutes"`
+	TrackedSeconds   int64 `json:"tracked_seconds"`
+	RemainingSeconds int64 `json:"remaining_seconds"`
+	Percent          int   `json:"percent"`
+}
+
+type projectBudgetStatus struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	Name      string    `json:"name"`
+	budgetStatus
+}
+
+func newBudgetStatus(budgetMinutes int32, tracked int64) budgetStatus {
+	budget := int64(budgetMinutes) * 60
+	return budgetStatus{
+		BudgetMinutes:    budgetMinutes,
+		TrackedSeconds:   tracked,
+		RemainingSeconds: budget - tracked,
+		Percent:          int(tracked * 100 / budget),
+	}
+}
+
+// budgetStage is the alert a budget is due for, if any
+func budgetStage(status budgetStatus, warnedAt, exhaustedAt sql.NullTime) string {
+	switch {
+	case status.Percent >= 100 && !exhaustedAt.Valid:
+		return "exhausted"
+	case status.Percent >= budgetWarningPercent && !warnedAt.Valid:
+		return "warning"
+	}
+	return ""
+}
+
+// budgetProject is a project's budget as seen during one tick, shared by its running tasks
+type budgetProject struct {
+	project  database.Project
+	status   *projectBudgetStatus
+	stage    string
+	notified bool
+}
+
+// BudgetService follows running tasks with a time budget, their own or their
+// project's, alerts at 75% and 100% and pauses the timer when the task asks for it
+type BudgetService struct {
+	queries   *database.Queries
+	notify    func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	broadcast func(userID uuid.UUID, event string, data interface{})
+}
+
+func NewBudgetService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), broadcast func(userID uuid.UUID, event string, data interface{})) *BudgetService {
+	return &BudgetService{
+		queries:   queries,
+		notify:    notify,
+		broadcast: broadcast,
+	}
+}
+
+func (s *BudgetService) Tick(ctx context.Context) error {
+	tasks, err := s.queries.GetRunningBudgetedTasks(ctx)
+	if err != nil {
+		log.Printf("BudgetService: Failed to load running tasks: %v", err)
+		return err
+	}
+
+	now := time.Now()
+	projects := make(map[uuid.UUID]*budgetProject)
+	for _, task := range tasks {
+		if err := s.checkTask(ctx, task, now, projects); err != nil {
+			log.Printf("BudgetService: Failed to check task %s: %v", task.ID, err)
+			// Continue checking other tasks
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *BudgetService) checkTask(ctx context.Context, task database.Task, now time.Time, projects map[uuid.UUID]*budgetProject) error {
+	nowMs := now.UnixMilli()
+
+	var taskStatus *budgetStatus
+	taskStage := ""
+	if task.BudgetMinutes.Valid && task.BudgetMinutes.Int32 > 0 {
+		earlier, err := s.queries.GetTaskEarlierTrackedSeconds(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		current, err := taskTrackedSeconds(task, nowMs)
+		if err != nil {
+			return err
+		}
+		status := newBudgetStatus(task.BudgetMinutes.Int32, earlier+current)
+		taskStatus = &status
+
+		taskStage = budgetStage(status, task.BudgetWarnedAt, task.BudgetExhaustedAt)
+		if err := s.markTask(ctx, task.ID, taskStage, now); err != nil {
+			return err
+		}
+	}
+
+	var project *budgetProject
+	if task.ProjectID.Valid {
+		var err error
+		project, err = s.loadProject(ctx, task.ProjectID.UUID, now, projects)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Alerts go out once; the pause policy applies to every running task of an
+	// exhausted project, resuming afterwards is left to the user
+	paused := false
+	if task.BudgetPolicy == "pause" && (taskStage == "exhausted" || (project != nil && project.stage == "exhausted")) {
+		if err := s.pauseTask(ctx, task, now); err != nil {
+			return err
+		}
+		paused = true
+	}
+
+	if taskStage != "" {
+		if err := s.sendNotification(ctx, task, nil, *taskStatus, taskStage, paused, now); err != nil {
+			log.Printf("BudgetService: Failed to notify for task %s: %v", task.ID, err)
+		}
+	}
+	// Once per project and tick, on the first running task that reached it
+	if project != nil && project.stage != "" && !project.notified {
+		project.notified = true
+		if err := s.sendNotification(ctx, task, &project.project, project.status.budgetStatus, project.stage, paused, now); err != nil {
+			log.Printf("BudgetService: Failed to notify for project %s: %v", project.project.ID, err)
+		}
+	}
+
+	var projectStatus *projectBudgetStatus
+	if project != nil {
+		projectStatus = project.status
+	}
+	if !paused && !budgetPushDue(taskStatus, projectStatus, taskStage != "", now) {
+		return nil
+	}
+
+	s.emit(task.UserID, "budget_remaining", struct {
+		TaskID  uuid.UUID            `json:"task_id"`
+		Paused  bool                 `json:"paused"`
+		Task    *budgetStatus        `json:"task"`
+		Project *projectBudgetStatus `json:"project"`
+	}{
+		TaskID:  task.ID,
+		Paused:  paused,
+		Task:    taskStatus,
+		Project: projectStatus,
+	})
+	return nil
+}
+
+func budgetPushDue(taskStatus *budgetStatus, projectStatus *projectBudgetStatus, alerted bool, now time.Time) bool {
+	if alerted || now.Minute()%budgetPushEveryMinute == 0 {
+		return true
+	}
+	if taskStatus != nil && taskStatus.RemainingSeconds <= budgetCloseSeconds {
+		return true
+	}
+	return projectStatus != nil && projectStatus.RemainingSeconds <= budgetCloseSeconds
+}
+
+// loadProject reads a project's budget once per tick and marks the alert it is due for.
+// Projects without a budget come back nil.
+func (s *BudgetService) loadProject(ctx context.Context, projectID uuid.UUID, now time.Time, projects map[uuid.UUID]*budgetProject) (*budgetProject, error) {
+	if project, ok := projects[projectID]; ok {
+		return project, nil
+	}
+
+	project, err := s.queries.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !project.BudgetMinutes.Valid || project.BudgetMinutes.Int32 <= 0 {
+		projects[projectID] = nil
+		return nil, nil
+	}
+
+	tracked, err := s.queries.GetProjectTrackedSeconds(ctx, database.GetProjectTrackedSecondsParams{
+		NowMs:     now.UnixMilli(),
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status := newBudgetStatus(project.BudgetMinutes.Int32, tracked)
+	stage := budgetStage(status, project.BudgetWarnedAt, project.BudgetExhaustedAt)
+	stamp := sql.NullTime{Time: now.UTC(), Valid: true}
+	switch stage {
+	case "warning":
+		err = s.queries.MarkProjectBudgetWarned(ctx, database.MarkProjectBudgetWarnedParams{
+			ID:             projectID,
+			BudgetWarnedAt: stamp,
+		})
+	case "exhausted":
+		err = s.queries.MarkProjectBudgetExhausted(ctx, database.MarkProjectBudgetExhaustedParams{
+			ID:                projectID,
+			BudgetExhaustedAt: stamp,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stage != "" {
+		log.Printf("BudgetService: Project %s reached %d%% of its %d minute budget", projectID, status.Percent, project.BudgetMinutes.Int32)
+	}
+
+	loaded := &budgetProject{
+		project: project,
+		status:  &projectBudgetStatus{ProjectID: project.ID, Name: project.Name, budgetStatus: status},
+		stage:   stage,
+	}
+	projects[projectID] = loaded
+	return loaded, nil
+}
+
+func (s *BudgetService) markTask(ctx context.Context, taskID uuid.UUID, stage string, now time.Time) error {
+	stamp := sql.NullTime{Time: now.UTC(), Valid: true}
+	switch stage {
+	case "warning":
+		return s.queries.MarkTaskBudgetWarned(ctx, database.MarkTaskBudgetWarnedParams{
+			ID:             taskID,
+			BudgetWarnedAt: stamp,
+		})
+	case "exhausted":
+		return s.queries.MarkTaskBudgetExhausted(ctx, database.MarkTaskBudgetExhaustedParams{
+			ID:                taskID,
+			BudgetExhaustedAt: stamp,
+		})
+	}
+	return nil
+}
+
+func (s *BudgetService) pauseTask(ctx context.Context, task database.Task, now time.Time) error {
+	tracked, err := taskTrackedSeconds(task, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+	duration, err := durationIntToStr(tracked)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.queries.ToggleTask(ctx, database.ToggleTaskParams{
+		ID:             task.ID,
+		IsActive:       false,
+		ToggledAt:      sql.NullInt64{Valid: false},
+		Duration:       duration,
+		LastModifiedAt: now.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	recordTaskRevision(ctx, s.queries, uuid.NullUUID{}, "budget_pause", &task, updated)
+	log.Printf("BudgetService: Paused task %s, its budget is used up", task.ID)
+
+	s.emit(task.UserID, "related_task_toggled", updated)
+	return nil
+}
+
+// sendNotification alerts about the task's own budget, or its project's when project is set
+func (s *BudgetService) sendNotification(ctx context.Context, task database.Task, project *database.Project, status budgetStatus, stage string, paused bool, now time.Time) error {
+	if s.notify == nil {
+		return nil
+	}
+
+	scope := "task"
+	subject := fmt.Sprintf("'%s'", task.Title)
+	values := map[string]interface{}{
+		"kind":            "budget",
+		"stage":           stage,
+		"task_id":         task.ID,
+		"title":           task.Title,
+		"budget_minutes":  status.BudgetMinutes,
+		"tracked_seconds": status.TrackedSeconds,
+		"paused":          paused,
+	}
+	if project != nil {
+		scope = "project"
+		subject = fmt.Sprintf("Project '%s'", project.Name)
+		values["project_id"] = project.ID
+		values["project_name"] = project.Name
+	}
+	values["scope"] = scope
+
+	payload, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	title := "Budget Almost Used"
+	description := fmt.Sprintf("%s has used %d%% of its %d minute budget.", subject, status.Percent, status.BudgetMinutes)
+	priority := "normal"
+	if stage == "exhausted" {
+		title = "Budget Used Up"
+		description = fmt.Sprintf("%s has used up its %d minute budget.", subject, status.BudgetMinutes)
+		priority = "high"
+	}
+	if paused {
+		description += fmt.Sprintf(" '%s' was paused.", task.Title)
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           task.UserID,
+		Title:            title,
+		Description:      sql.NullString{String: description, Valid: true},
+		Status:           "unseen",
+		NotificationType: "budget",
+		Payload:          payload,
+		Priority:         priority,
+		ExpiresAt:        sql.NullTime{Time: now.Add(24 * time.Hour), Valid: true},
+		LastModifiedAt:   now.UnixMilli(),
+	})
+	return err
+}
+
+func (s *BudgetService) emit(userID uuid.UUID, event string, data interface{}) {
+	if s.broadcast == nil {
+		return
+	}
+	s.broadcast(userID, event, data)
+}
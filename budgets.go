@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+// budgetPolicies are what happens to a running task once its budget, or its project's, is used up
+var budgetPolicies = map[string]struct{}{
+	"continue": {},
+	"pause":    {},
+}
+
+// nullableBudget treats a missing or non-positive budget as "no budget"
+func nullableBudget(minutes *int32) sql.NullInt32 {
+	if minutes == nil || *minutes <= 0 {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *minutes, Valid: true}
+}
+
+// WSOnTaskSetBudget sets or clears a task's time budget. Changing the minutes
+// starts the 75% and 100% alerts over, the policy alone keeps them.
+func (cfg *config) WSOnTaskSetBudget(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_set_budget").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID        uuid.UUID `json:"task_id"`
+			BudgetMinutes *int32    `json:"budget_minutes"`
+			Policy        string    `json:"policy"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if _, ok := budgetPolicies[payload.Data.Policy]; !ok && payload.Data.Policy != "" {
+		return sendError(c, "invalid_request", "policy must be 'continue' or 'pause'", 400)
+	}
+
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	policy := payload.Data.Policy
+	if policy == "" {
+		policy = task.BudgetPolicy
+	}
+
+	previousTask := task
+	task, err = cfg.DB.SetTaskBudget(ctx, database.SetTaskBudgetParams{
+		ID:             task.ID,
+		BudgetMinutes:  nullableBudget(payload.Data.BudgetMinutes),
+		BudgetPolicy:   policy,
+		LastModifiedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		logDBError("Failed to set budget of task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to update task", 500)
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "edit", &previousTask, task)
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	return nil
+}
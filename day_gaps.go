@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+// Gaps shorter than this are switching between tasks rather than lost time
+const defaultDayGapMinMinutes = 5
+
+// taskInterval is a stretch of time covered by one task
+type taskInterval struct {
+	TaskID uuid.UUID
+	Start  time.Time
+	End    time.Time
+}
+
+type dayGap struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Minutes int64     `json:"minutes"`
+	// The task that stopped when the gap began and the one that started when it ended
+	BeforeTaskID *uuid.UUID `json:"before_task_id"`
+	AfterTaskID  *uuid.UUID `json:"after_task_id"`
+}
+
+type dayGapsReport struct {
+	Date             string     `json:"date"`
+	WorkingDay       bool       `json:"working_day"`
+	WindowStart      *time.Time `json:"window_start"`
+	WindowEnd        *time.Time `json:"window_end"`
+	TrackedSeconds   int64      `json:"tracked_seconds"`
+	UntrackedSeconds int64      `json:"untracked_seconds"`
+	Gaps             []dayGap   `json:"gaps"`
+}
+
+// userWorkWindow returns the user's working hours on the given local date. Hours
+// that span midnight end on the next day.
+func userWorkWindow(user database.User, date time.Time) (time.Time, time.Time, bool) {
+	if !user.WorkStartMinutes.Valid || !user.WorkEndMinutes.Valid {
+		return time.Time{}, time.Time{}, false
+	}
+
+	weekday := int32(date.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	workDay := false
+	for _, day := range user.WorkDays {
+		if day == weekday {
+			workDay = true
+			break
+		}
+	}
+	if !workDay {
+		return time.Time{}, time.Time{}, false
+	}
+
+	loc := userLocation(user)
+	startMinute, endMinute := int(user.WorkStartMinutes.Int32), int(user.WorkEndMinutes.Int32)
+	start := time.Date(date.Year(), date.Month(), date.Day(), startMinute/60, startMinute%60, 0, 0, loc)
+	end := time.Date(date.Year(), date.Month(), date.Day(), endMinute/60, endMinute%60, 0, 0, loc)
+	if endMinute <= startMinute {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start.UTC(), end.UTC(), true
+}
+
+// runningIntervals replays task revisions, ordered by task and time, into the
+// stretches each task's timer ran. A run starts at the toggled_at of the first
+// running snapshot and ends with the next snapshot that is not running. Revisions
+// from windowEnd on only close a run still open at windowEnd, whatever they changed;
+// runs still open after the last revision end at now when the task is running, and
+// are dropped otherwise.
+func runningIntervals(revisions []database.TaskRevision, running map[uuid.UUID]bool, windowEnd, now time.Time) []taskInterval {
+	var intervals []taskInterval
+	var open *taskInterval
+
+	closeOpen := func() {
+		if open != nil && running[open.TaskID] {
+			open.End = now
+			intervals = append(intervals, *open)
+		}
+		open = nil
+	}
+
+	for i, revision := range revisions {
+		if i > 0 && revision.TaskID != revisions[i-1].TaskID {
+			closeOpen()
+		}
+
+		if !revision.CreatedAt.Before(windowEnd) {
+			if open != nil {
+				open.End = windowEnd
+				intervals = append(intervals, *open)
+				open = nil
+			}
+			continue
+		}
+
+		var snapshot database.Task
+		if err := json.Unmarshal(revision.Snapshot, &snapshot); err != nil {
+			continue
+		}
+		active := snapshot.IsActive && !snapshot.IsCompleted
+
+		// toggled_at is the client's clock, only trusted when it is not after the revision
+		var toggledAt time.Time
+		if snapshot.ToggledAt.Valid && snapshot.ToggledAt.Int64 > 0 {
+			toggledAt = time.UnixMilli(snapshot.ToggledAt.Int64)
+		}
+
+		switch {
+		case active && open == nil:
+			start := revision.CreatedAt
+			if !toggledAt.IsZero() && toggledAt.Before(start) {
+				start = toggledAt
+			}
+			open = &taskInterval{TaskID: revision.TaskID, Start: start}
+		case !active && open != nil:
+			end := revision.CreatedAt
+			if !toggledAt.IsZero() && toggledAt.After(open.Start) && toggledAt.Before(end) {
+				end = toggledAt
+			}
+			open.End = end
+			intervals = append(intervals, *open)
+			open = nil
+		}
+	}
+	closeOpen()
+
+	return intervals
+}
+
+// subtractIntervals cuts every cut out of the intervals, splitting them where needed
+func subtractIntervals(intervals, cuts []taskInterval) []taskInterval {
+	for _, cut := range cuts {
+		var pieces []taskInterval
+		for _, interval := range intervals {
+			if !cut.Start.Before(interval.End) || !cut.End.After(interval.Start) {
+				pieces = append(pieces, interval)
+				continue
+			}
+			if interval.Start.Before(cut.Start) {
+				pieces = append(pieces, taskInterval{TaskID: interval.TaskID, Start: interval.Start, End: cut.Start})
+			}
+			if cut.End.Before(interval.End) {
+				pieces = append(pieces, taskInterval{TaskID: interval.TaskID, Start: cut.End, End: interval.End})
+			}
+		}
+		intervals = pieces
+	}
+	return intervals
+}
+
+// findDayGaps walks the covered intervals through the window and returns what is
+// left uncovered, along with the untracked seconds. Stretches shorter than
+// minMinutes are neither listed nor counted as untracked.
+func findDayGaps(intervals []taskInterval, windowStart, windowEnd time.Time, minMinutes int) ([]dayGap, int64) {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+
+	gaps := []dayGap{}
+	var uncovered time.Duration
+	addGap := func(start, end time.Time, before, after *uuid.UUID) {
+		if end.Sub(start) < time.Duration(minMinutes)*time.Minute {
+			return
+		}
+		uncovered += end.Sub(start)
+		gaps = append(gaps, dayGap{
+			Start:        start,
+			End:          end,
+			Minutes:      int64(end.Sub(start).Minutes()),
+			BeforeTaskID: before,
+			AfterTaskID:  after,
+		})
+	}
+
+	cursor := windowStart
+	var lastTask *uuid.UUID
+	for _, interval := range intervals {
+		if !interval.End.After(cursor) || !interval.Start.Before(windowEnd) {
+			continue
+		}
+		if interval.Start.After(cursor) {
+			taskID := interval.TaskID
+			addGap(cursor, interval.Start, lastTask, &taskID)
+		}
+		taskID := interval.TaskID
+		cursor = interval.End
+		lastTask = &taskID
+		if !cursor.Before(windowEnd) {
+			break
+		}
+	}
+	if cursor.Before(windowEnd) {
+		addGap(cursor, windowEnd, lastTask, nil)
+	}
+
+	return gaps, int64(uncovered.Seconds())
+}
+
+// buildDayGaps finds the untracked stretches of the user's working window on the
+// given local date. Timers count as tracked except for idle time that was not
+// kept; past intervals booked with time_adjust count as well. The part of the
+// window still ahead is left out.
+func buildDayGaps(ctx context.Context, queries *database.Queries, user database.User, date time.Time, minMinutes int, now time.Time) (dayGapsReport, error) {
+	report := dayGapsReport{Date: date.Format("2006-01-02"), Gaps: []dayGap{}}
+
+	windowStart, windowEnd, ok := userWorkWindow(user, date)
+	if !ok {
+		return report, nil
+	}
+	report.WorkingDay = true
+	report.WindowStart = &windowStart
+	report.WindowEnd = &windowEnd
+
+	if windowEnd.After(now) {
+		windowEnd = now
+	}
+	if !windowEnd.After(windowStart) {
+		return report, nil
+	}
+
+	revisions, err := queries.GetTaskRevisionsInRange(ctx, database.GetTaskRevisionsInRangeParams{
+		UserID:  user.ID,
+		StartAt: windowStart,
+		EndAt:   windowEnd,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	runningIDs, err := queries.GetRunningTaskIDsByUser(ctx, user.ID)
+	if err != nil {
+		return report, err
+	}
+	running := make(map[uuid.UUID]bool, len(runningIDs))
+	for _, id := range runningIDs {
+		running[id] = true
+	}
+
+	idlePeriods, err := queries.GetUntrackedIdlePeriodsInRange(ctx, database.GetUntrackedIdlePeriodsInRangeParams{
+		UserID:  user.ID,
+		StartAt: windowStart,
+		EndAt:   windowEnd,
+	})
+	if err != nil {
+		return report, err
+	}
+	cuts := make([]taskInterval, 0, len(idlePeriods))
+	for _, period := range idlePeriods {
+		cuts = append(cuts, taskInterval{TaskID: period.TaskID, Start: period.IdleFrom, End: period.IdleUntil})
+	}
+
+	adjustments, err := queries.GetIntervalAdjustmentsInRange(ctx, database.GetIntervalAdjustmentsInRangeParams{
+		UserID:  user.ID,
+		StartAt: windowStart,
+		EndAt:   windowEnd,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	intervals := subtractIntervals(runningIntervals(revisions, running, windowEnd, now), cuts)
+	for _, adjustment := range adjustments {
+		intervals = append(intervals, taskInterval{
+			TaskID: adjustment.TaskID,
+			Start:  adjustment.StartedAt.Time,
+			End:    adjustment.EndedAt.Time,
+		})
+	}
+
+	// Short switches between tasks count as tracked, so the two always add up to the window
+	report.Gaps, report.UntrackedSeconds = findDayGaps(intervals, windowStart, windowEnd, minMinutes)
+	report.TrackedSeconds = int64(windowEnd.Sub(windowStart).Seconds()) - report.UntrackedSeconds
+	return report, nil
+}
+
+func (cfg *config) WSOnDayGaps(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("day_gaps").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			Date       string `json:"date"`
+			MinMinutes *int   `json:"min_minutes"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if !client.User.WorkStartMinutes.Valid || !client.User.WorkEndMinutes.Valid {
+		return sendError(c, "invalid_request", "Working hours are not set", 400)
+	}
+
+	now := time.Now()
+	loc := userLocation(client.User)
+	date := now.In(loc)
+	if payload.Data.Date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", payload.Data.Date, loc)
+		if err != nil {
+			return sendError(c, "invalid_request", "date must be formatted as YYYY-MM-DD", 400)
+		}
+		date = parsed
+	}
+
+	minMinutes := defaultDayGapMinMinutes
+	if payload.Data.MinMinutes != nil {
+		if *payload.Data.MinMinutes < 0 {
+			return sendError(c, "invalid_request", "min_minutes cannot be negative", 400)
+		}
+		minMinutes = *payload.Data.MinMinutes
+	}
+
+	report, err := buildDayGaps(ctx, cfg.DB, client.User, date, minMinutes, now)
+	if err != nil {
+		logDBError("Failed to find day gaps for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to find gaps", 500)
+	}
+
+	return cfg.WSClientManager.SendToClient(ctx, "day_gaps", SID, report)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+// A summary this long after the working window ended is stale, the server was down
+const dayGapsNotifyGrace = time.Hour
+
+// DayGapsService sends users who asked for it a summary of the untracked parts
+// of their working window once it is over
+type DayGapsService struct {
+	queries *database.Queries
+	notify  func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+}
+
+func NewDayGapsService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)) *DayGapsService {
+	return &DayGapsService{
+		queries: queries,
+		notify:  notify,
+	}
+}
+
+func (s *DayGapsService) Tick(ctx context.Context) error {
+	users, err := s.queries.GetUsersWithDayGapsNotify(ctx)
+	if err != nil {
+		log.Printf("DayGapsService: Failed to load users: %v", err)
+		return err
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		if err := s.checkUser(ctx, user, now); err != nil {
+			log.Printf("DayGapsService: Failed to check user %s: %v", user.ID, err)
+			// Continue with other users
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *DayGapsService) checkUser(ctx context.Context, user database.User, now time.Time) error {
+	// Yesterday too, a window that spans midnight ends on the next local day
+	local := now.In(userLocation(user))
+	for _, date := range []time.Time{local.AddDate(0, 0, -1), local} {
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		if user.DayGapsNotifiedOn.Valid && !user.DayGapsNotifiedOn.Time.Before(day) {
+			continue
+		}
+
+		_, windowEnd, ok := userWorkWindow(user, date)
+		if !ok || now.Before(windowEnd) {
+			continue
+		}
+
+		err := s.queries.SetUserDayGapsNotifiedOn(ctx, database.SetUserDayGapsNotifiedOnParams{
+			ID:                user.ID,
+			DayGapsNotifiedOn: sql.NullTime{Time: day, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		user.DayGapsNotifiedOn = sql.NullTime{Time: day, Valid: true}
+
+		if now.Sub(windowEnd) > dayGapsNotifyGrace {
+			continue
+		}
+
+		report, err := buildDayGaps(ctx, s.queries, user, date, defaultDayGapMinMinutes, now)
+		if err != nil {
+			return err
+		}
+		if len(report.Gaps) == 0 {
+			continue
+		}
+		if err := s.sendNotification(ctx, user, report, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendNotification lists the gaps, each with a time_adjust request that books it
+// on a task; the tasks around the gap are offered as the likely candidates
+func (s *DayGapsService) sendNotification(ctx context.Context, user database.User, report dayGapsReport, now time.Time) error {
+	if s.notify == nil {
+		return nil
+	}
+
+	type gapAction struct {
+		Event string                 `json:"event"`
+		Data  map[string]interface{} `json:"data"`
+	}
+	type gapEntry struct {
+		dayGap
+		SuggestedTaskIDs []uuid.UUID `json:"suggested_task_ids"`
+		Action           gapAction   `json:"action"`
+	}
+
+	gaps := make([]gapEntry, 0, len(report.Gaps))
+	for _, gap := range report.Gaps {
+		suggested := []uuid.UUID{}
+		for _, taskID := range []*uuid.UUID{gap.BeforeTaskID, gap.AfterTaskID} {
+			if taskID != nil && (len(suggested) == 0 || suggested[0] != *taskID) {
+				suggested = append(suggested, *taskID)
+			}
+		}
+		gaps = append(gaps, gapEntry{
+			dayGap:           gap,
+			SuggestedTaskIDs: suggested,
+			Action: gapAction{
+				Event: "time_adjust",
+				Data: map[string]interface{}{
+					"kind":       "interval",
+					"started_at": gap.Start,
+					"ended_at":   gap.End,
+					"reason":     "Untracked time on " + report.Date,
+				},
+			},
+		})
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":              "day_gaps",
+		"date":              report.Date,
+		"window_start":      report.WindowStart,
+		"window_end":        report.WindowEnd,
+		"tracked_seconds":   report.TrackedSeconds,
+		"untracked_seconds": report.UntrackedSeconds,
+		"gaps":              gaps,
+	})
+	if err != nil {
+		return err
+	}
+
+	untracked := formatSuggestionDuration(time.Duration(report.UntrackedSeconds) * time.Second)
+	description := fmt.Sprintf("%s of your working hours had nothing running, in %d gaps.", untracked, len(report.Gaps))
+	if len(report.Gaps) == 1 {
+		description = fmt.Sprintf("%s of your working hours had nothing running.", untracked)
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		Title:            "Untracked Time Today",
+		Description:      sql.NullString{String: description, Valid: true},
+		Status:           "unseen",
+		NotificationType: "day_gaps",
+		Payload:          payload,
+		Priority:         "normal",
+		ExpiresAt:        sql.NullTime{Time: now.Add(7 * 24 * time.Hour), Valid: true},
+		LastModifiedAt:   now.UnixMilli(),
+	})
+	return err
+}
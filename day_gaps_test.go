@@ -0,0 +1,115 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+func TestRunningIntervalsDayGaps(t *testing.T) {
+	taskID := uuid.New()
+	day := time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	windowStart, windowEnd := at(9, 0), at(17, 0)
+	now := at(19, 0)
+
+	// revision is a snapshot of the task at the given time, toggled at that time when running
+	revision := func(when time.Time, active bool) database.TaskRevision {
+		snapshot := database.Task{ID: taskID, IsActive: active}
+		if active {
+			snapshot.ToggledAt = sql.NullInt64{Int64: when.UnixMilli(), Valid: true}
+		}
+		raw, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return database.TaskRevision{ID: uuid.New(), TaskID: taskID, Snapshot: raw, CreatedAt: when}
+	}
+
+	type span struct{ start, end time.Time }
+
+	tests := []struct {
+		name       string
+		revisions  []database.TaskRevision
+		running    bool
+		minMinutes int
+		gaps       []span
+		untracked  time.Duration
+	}{
+		{
+			name:      "stopped after the window ends",
+			revisions: []database.TaskRevision{revision(at(14, 0), true), revision(at(18, 0), false)},
+			gaps:      []span{{at(9, 0), at(14, 0)}},
+			untracked: 5 * time.Hour,
+		},
+		{
+			name:      "edited while still running after the window ends",
+			revisions: []database.TaskRevision{revision(at(14, 0), true), revision(at(18, 0), true)},
+			gaps:      []span{{at(9, 0), at(14, 0)}},
+			untracked: 5 * time.Hour,
+		},
+		{
+			name:      "stopped inside the window",
+			revisions: []database.TaskRevision{revision(at(10, 0), true), revision(at(12, 0), false)},
+			gaps:      []span{{at(9, 0), at(10, 0)}, {at(12, 0), at(17, 0)}},
+			untracked: 6 * time.Hour,
+		},
+		{
+			name:      "running since before the window",
+			revisions: []database.TaskRevision{revision(at(8, 0), true), revision(at(10, 0), false)},
+			gaps:      []span{{at(10, 0), at(17, 0)}},
+			untracked: 7 * time.Hour,
+		},
+		{
+			name:      "still running now",
+			revisions: []database.TaskRevision{revision(at(14, 0), true)},
+			running:   true,
+			gaps:      []span{{at(9, 0), at(14, 0)}},
+			untracked: 5 * time.Hour,
+		},
+		{
+			name:      "open run of a task that is not running is dropped",
+			revisions: []database.TaskRevision{revision(at(14, 0), true)},
+			gaps:      []span{{at(9, 0), at(17, 0)}},
+			untracked: 8 * time.Hour,
+		},
+		{
+			name: "short switches count as tracked",
+			revisions: []database.TaskRevision{
+				revision(at(9, 0), true),
+				revision(at(12, 0), false),
+				revision(at(12, 3), true),
+				revision(at(18, 0), false),
+			},
+			minMinutes: 5,
+			gaps:       []span{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			running := map[uuid.UUID]bool{taskID: tt.running}
+			intervals := runningIntervals(tt.revisions, running, windowEnd, now)
+			gaps, untracked := findDayGaps(intervals, windowStart, windowEnd, tt.minMinutes)
+
+			if len(gaps) != len(tt.gaps) {
+				t.Fatalf("gaps = %+v, want %d gaps", gaps, len(tt.gaps))
+			}
+			for i, gap := range gaps {
+				if !gap.Start.Equal(tt.gaps[i].start) || !gap.End.Equal(tt.gaps[i].end) {
+					t.Errorf("gap %d = %s-%s, want %s-%s", i, gap.Start.Format("15:04"), gap.End.Format("15:04"),
+						tt.gaps[i].start.Format("15:04"), tt.gaps[i].end.Format("15:04"))
+				}
+			}
+			if untracked != int64(tt.untracked.Seconds()) {
+				t.Errorf("untracked = %d, want %d", untracked, int64(tt.untracked.Seconds()))
+			}
+		})
+	}
+}
@@ -73,12 +73,16 @@ func (s *DispatcherService) processJob(ctx context.Context, job database.ClaimDu
 		if kind == "reminder" {
 			notificationType = "reminder"
 			title = "Reminder"
+		} else if kind == "project" {
+			title = "Project Deadline"
 		}
 	} else {
-		// Pre-notification (only for tasks now)
+		// Pre-notification (tasks and project deadlines)
 		if kind == "task" {
 			notificationType = "reminder"
 			title = "Upcoming Task"
+		} else if kind == "project" {
+			title = "Upcoming Project Deadline"
 		}
 	}
 
@@ -88,6 +92,8 @@ func (s *DispatcherService) processJob(ctx context.Context, job database.ClaimDu
 			// At-time notification (only for reminders)
 			if kind == "reminder" {
 				description = "Reminder: " + taskTitle
+			} else if kind == "project" {
+				description = "Your project '" + taskTitle + "' is due now."
 			}
 		} else {
 			// Pre-notification (only for tasks)
@@ -99,6 +105,13 @@ func (s *DispatcherService) processJob(ctx context.Context, job database.ClaimDu
 				} else {
 					description = "Your task '" + taskTitle + "' is due in " + formatHours(hours) + "."
 				}
+			} else if kind == "project" {
+				hours := job.OffsetMinutes / 60
+				if hours >= 24 {
+					description = "Your project '" + taskTitle + "' is due in " + formatDays(hours/24) + "."
+				} else {
+					description = "Your project '" + taskTitle + "' is due in " + formatHours(hours) + "."
+				}
 			}
 		}
 	}
@@ -112,8 +125,8 @@ func (s *DispatcherService) processJob(ctx context.Context, job database.ClaimDu
 		priority = "low"
 	}
 
-	// Create notification in database
-	notification, err := s.queries.CreateNotification(ctx, database.CreateNotificationParams{
+	// Create notification in database and deliver it
+	_, err := s.Notify(ctx, database.CreateNotificationParams{
 		ID:               uuid.New(),
 		UserID:           job.UserID,
 		Title:            title,
@@ -126,20 +139,30 @@ func (s *DispatcherService) processJob(ctx context.Context, job database.ClaimDu
 		LastModifiedAt:   time.Now().UnixMilli(),
 	})
 	if err != nil {
-		log.Printf("DispatcherService: Failed to create notification: %v", err)
 		return err
 	}
 
+	log.Printf("DispatcherService: Successfully processed job %s", job.ID)
+	return nil
+}
+
+// Notify stores a notification and pushes it to the user's connected clients.
+func (s *DispatcherService) Notify(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error) {
+	notification, err := s.queries.CreateNotification(ctx, params)
+	if err != nil {
+		log.Printf("DispatcherService: Failed to create notification: %v", err)
+		return database.Notification{}, err
+	}
+
 	// Send to client via WebSocket
 	if s.sendToClient != nil {
-		if err := s.sendToClient(job.UserID, notification); err != nil {
+		if err := s.sendToClient(params.UserID, notification); err != nil {
 			log.Printf("DispatcherService: Failed to send notification to client: %v", err)
 			// Don't return error - notification is already in database
 		}
 	}
 
-	log.Printf("DispatcherService: Successfully processed job %s", job.ID)
-	return nil
+	return notification, nil
 }
 
 // Helper functions for formatting time descriptions
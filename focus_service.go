@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+// focusEvent is sent with every focus_* event so devices can render the session and its task
+type focusEvent struct {
+	Session database.FocusSession `json:"session"`
+	Task    database.Task         `json:"task"`
+}
+
+type FocusService struct {
+	queries   *database.Queries
+	notify    func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	broadcast func(userID uuid.UUID, event string, data interface{})
+}
+
+func NewFocusService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), broadcast func(userID uuid.UUID, event string, data interface{})) *FocusService {
+	return &FocusService{
+		queries:   queries,
+		notify:    notify,
+		broadcast: broadcast,
+	}
+}
+
+// Start opens a session on the task and starts its timer for the first work interval
+func (s *FocusService) Start(ctx context.Context, task database.Task, workMinutes, breakMinutes, cycles int32) (database.FocusSession, error) {
+	session, err := s.queries.CreateFocusSession(ctx, database.CreateFocusSessionParams{
+		ID:            uuid.New(),
+		UserID:        task.UserID,
+		TaskID:        task.ID,
+		WorkMinutes:   workMinutes,
+		BreakMinutes:  breakMinutes,
+		CyclesPlanned: cycles,
+		PhaseEndsAt:   time.Now().Add(time.Duration(workMinutes) * time.Minute),
+	})
+	if err != nil {
+		return database.FocusSession{}, err
+	}
+
+	task, err = s.setTaskRunning(ctx, task, true)
+	if err != nil {
+		return session, err
+	}
+
+	s.emit(task.UserID, "focus_work_started", session, task)
+	return session, nil
+}
+
+// Stop cancels a running session and pauses its task
+func (s *FocusService) Stop(ctx context.Context, session database.FocusSession) (database.FocusSession, error) {
+	session, err := s.queries.EndFocusSession(ctx, database.EndFocusSessionParams{
+		ID:              session.ID,
+		Status:          "cancelled",
+		CyclesCompleted: session.CyclesCompleted,
+	})
+	if err != nil {
+		return database.FocusSession{}, err
+	}
+
+	task, err := s.queries.GetTaskByID(ctx, session.TaskID)
+	if err != nil {
+		return session, err
+	}
+
+	task, err = s.setTaskRunning(ctx, task, false)
+	if err != nil {
+		return session, err
+	}
+
+	s.emit(session.UserID, "focus_session_stopped", session, task)
+	return session, nil
+}
+
+func (s *FocusService) Tick(ctx context.Context) error {
+	sessions, err := s.queries.GetDueFocusSessions(ctx)
+	if err != nil {
+		log.Printf("FocusService: Failed to load due sessions: %v", err)
+		return err
+	}
+
+	for _, session := range sessions {
+		if err := s.advance(ctx, session); err != nil {
+			log.Printf("FocusService: Failed to advance session %s: %v", session.ID, err)
+			// Continue with other sessions
+			continue
+		}
+	}
+
+	return nil
+}
+
+// advance moves a session whose phase ended into the next one
+func (s *FocusService) advance(ctx context.Context, session database.FocusSession) error {
+	task, err := s.queries.GetTaskByID(ctx, session.TaskID)
+	if err != nil {
+		return err
+	}
+
+	// The task was completed mid-session, there is nothing left to focus on
+	if task.IsCompleted {
+		session, err = s.queries.EndFocusSession(ctx, database.EndFocusSessionParams{
+			ID:              session.ID,
+			Status:          "cancelled",
+			CyclesCompleted: session.CyclesCompleted,
+		})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.emit(session.UserID, "focus_session_stopped", session, task)
+		return nil
+	}
+
+	now := time.Now()
+
+	if session.Phase == "break" {
+		session, err = s.queries.AdvanceFocusSession(ctx, database.AdvanceFocusSessionParams{
+			ID:              session.ID,
+			Phase:           "work",
+			CyclesCompleted: session.CyclesCompleted,
+			PhaseEndsAt:     now.Add(time.Duration(session.WorkMinutes) * time.Minute),
+		})
+		if err == sql.ErrNoRows {
+			// Stopped while this tick was running
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		task, err = s.setTaskRunning(ctx, task, true)
+		if err != nil {
+			return err
+		}
+
+		s.emit(session.UserID, "focus_work_started", session, task)
+		return s.sendNotification(ctx, session, task, "work_started", "Back to Focus",
+			fmt.Sprintf("Break is over, '%s' is running again for %d minutes.", task.Title, session.WorkMinutes))
+	}
+
+	cycles := session.CyclesCompleted + 1
+	if cycles >= session.CyclesPlanned {
+		session, err = s.queries.EndFocusSession(ctx, database.EndFocusSessionParams{
+			ID:              session.ID,
+			Status:          "completed",
+			CyclesCompleted: cycles,
+		})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		task, err = s.setTaskRunning(ctx, task, false)
+		if err != nil {
+			return err
+		}
+
+		task, err = s.queries.IncrementTaskFocusSessions(ctx, database.IncrementTaskFocusSessionsParams{
+			ID:             task.ID,
+			LastModifiedAt: now.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+
+		s.emit(session.UserID, "focus_session_completed", session, task)
+		return s.sendNotification(ctx, session, task, "completed", "Focus Session Complete",
+			fmt.Sprintf("You finished %d focus intervals on '%s'.", cycles, task.Title))
+	}
+
+	session, err = s.queries.AdvanceFocusSession(ctx, database.AdvanceFocusSessionParams{
+		ID:              session.ID,
+		Phase:           "break",
+		CyclesCompleted: cycles,
+		PhaseEndsAt:     now.Add(time.Duration(session.BreakMinutes) * time.Minute),
+	})
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	task, err = s.setTaskRunning(ctx, task, false)
+	if err != nil {
+		return err
+	}
+
+	s.emit(session.UserID, "focus_break_started", session, task)
+	return s.sendNotification(ctx, session, task, "break_started", "Time for a Break",
+		fmt.Sprintf("Interval %d of %d on '%s' is done. Take %d minutes off.", cycles, session.CyclesPlanned, task.Title, session.BreakMinutes))
+}
+
+// setTaskRunning starts or pauses the task's timer the same way a client toggle would,
+// folding the running segment into the duration on pause
+func (s *FocusService) setTaskRunning(ctx context.Context, task database.Task, running bool) (database.Task, error) {
+	if task.IsActive == running {
+		return task, nil
+	}
+
+	nowMs := time.Now().UnixMilli()
+	params := database.ToggleTaskParams{
+		ID:             task.ID,
+		IsActive:       running,
+		Duration:       task.Duration,
+		LastModifiedAt: nowMs,
+	}
+
+	if running {
+		params.ToggledAt = sql.NullInt64{Int64: nowMs, Valid: true}
+	} else {
+		tracked, err := taskTrackedSeconds(task, nowMs)
+		if err != nil {
+			return task, err
+		}
+		params.Duration, err = durationIntToStr(tracked)
+		if err != nil {
+			return task, err
+		}
+		params.ToggledAt = sql.NullInt64{Valid: false}
+	}
+
+	updated, err := s.queries.ToggleTask(ctx, params)
+	if err != nil {
+		return task, err
+	}
+
+	recordTaskRevision(ctx, s.queries, uuid.NullUUID{}, "toggle", &task, updated)
+
+	if s.broadcast != nil {
+		s.broadcast(updated.UserID, "related_task_toggled", updated)
+	}
+	return updated, nil
+}
+
+func (s *FocusService) emit(userID uuid.UUID, event string, session database.FocusSession, task database.Task) {
+	if s.broadcast == nil {
+		return
+	}
+	s.broadcast(userID, event, focusEvent{Session: session, Task: task})
+}
+
+func (s *FocusService) sendNotification(ctx context.Context, session database.FocusSession, task database.Task, stage, title, description string) error {
+	if s.notify == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":             "focus",
+		"stage":            stage,
+		"session_id":       session.ID,
+		"task_id":          task.ID,
+		"title":            task.Title,
+		"phase_ends_at":    session.PhaseEndsAt,
+		"cycles_completed": session.CyclesCompleted,
+		"cycles_planned":   session.CyclesPlanned,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           session.UserID,
+		Title:            title,
+		Description:      sql.NullString{String: description, Valid: true},
+		Status:           "unseen",
+		NotificationType: "focus",
+		Payload:          payload,
+		Priority:         "high",
+		ExpiresAt:        sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true},
+		LastModifiedAt:   time.Now().UnixMilli(),
+	})
+	return err
+}
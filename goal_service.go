@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+// goalProgress is a goal with the time tracked towards it in the current period
+type goalProgress struct {
+	Goal           database.Goal `json:"goal"`
+	PeriodStart    time.Time     `json:"period_start"`
+	PeriodEnd      time.Time     `json:"period_end"`
+	TrackedSeconds int64         `json:"tracked_seconds"`
+	TargetSeconds  int64         `json:"target_seconds"`
+	Percent        int64         `json:"percent"`
+	Met            bool          `json:"met"`
+}
+
+type GoalService struct {
+	queries      *database.Queries
+	achievements *AchievementService
+	broadcast    func(userID uuid.UUID, event string, data interface{})
+}
+
+func NewGoalService(queries *database.Queries, achievements *AchievementService, broadcast func(userID uuid.UUID, event string, data interface{})) *GoalService {
+	return &GoalService{
+		queries:      queries,
+		achievements: achievements,
+		broadcast:    broadcast,
+	}
+}
+
+// Tick keeps progress of running tasks live and closes out at_most goals whose period ended
+func (s *GoalService) Tick(ctx context.Context) error {
+	users, err := s.queries.GetUsersWithGoals(ctx)
+	if err != nil {
+		log.Printf("GoalService: Failed to load users with goals: %v", err)
+		return err
+	}
+
+	for _, user := range users {
+		s.Refresh(ctx, user)
+	}
+
+	return nil
+}
+
+// Refresh recomputes the user's goals and pushes goal_progress to all of their sessions
+func (s *GoalService) Refresh(ctx context.Context, user database.User) {
+	progress, err := s.Progress(ctx, user)
+	if err != nil {
+		log.Printf("GoalService: Failed to compute progress for user %s: %v", user.ID, err)
+		return
+	}
+	if len(progress) == 0 || s.broadcast == nil {
+		return
+	}
+
+	s.broadcast(user.ID, "goal_progress", struct {
+		Goals []goalProgress `json:"goals"`
+	}{
+		Goals: progress,
+	})
+}
+
+// Progress computes every goal of the user for its current period, firing
+// achievements for goals that were met
+func (s *GoalService) Progress(ctx context.Context, user database.User) ([]goalProgress, error) {
+	goals, err := s.queries.GetGoalsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	progress := make([]goalProgress, 0, len(goals))
+	for _, goal := range goals {
+		periodStart, periodEnd := userPeriodBounds(user, goal.Period, now)
+		tracked, err := s.trackedSeconds(ctx, goal, periodStart, periodEnd, true, now)
+		if err != nil {
+			return nil, err
+		}
+
+		target := int64(goal.TargetMinutes) * 60
+		entry := goalProgress{
+			Goal:           goal,
+			PeriodStart:    periodStart,
+			PeriodEnd:      periodEnd,
+			TrackedSeconds: tracked,
+			TargetSeconds:  target,
+			Percent:        tracked * 100 / target,
+		}
+
+		if goal.Direction == "at_least" {
+			entry.Met = tracked >= target
+			if entry.Met {
+				entry.Goal = s.achieve(ctx, goal, periodStart, tracked)
+			}
+		} else {
+			// A limit is only met once its period is over, so look at the previous one
+			entry.Met = tracked <= target
+			entry.Goal = s.closeLimitPeriod(ctx, user, goal, periodStart, now)
+		}
+
+		progress = append(progress, entry)
+	}
+
+	return progress, nil
+}
+
+func (s *GoalService) trackedSeconds(ctx context.Context, goal database.Goal, start, end time.Time, includeOpen bool, now time.Time) (int64, error) {
+	return s.queries.GetTrackedSecondsForScope(ctx, database.GetTrackedSecondsForScopeParams{
+		UserID:      goal.UserID,
+		StartAt:     start,
+		EndAt:       end,
+		NowMs:       now.UnixMilli(),
+		IncludeOpen: includeOpen,
+		Scope:       goal.Scope,
+		ScopeValue:  goal.ScopeValue,
+	})
+}
+
+// closeLimitPeriod fires the achievement for an at_most goal whose previous period
+// stayed within the limit. Goals created during that period are skipped.
+func (s *GoalService) closeLimitPeriod(ctx context.Context, user database.User, goal database.Goal, periodStart, now time.Time) database.Goal {
+	previousStart, previousEnd := userPeriodBounds(user, goal.Period, periodStart.Add(-time.Second))
+	if goal.CreatedAt.After(previousStart) {
+		return goal
+	}
+	if goal.LastAchievedPeriodStart.Valid && !goal.LastAchievedPeriodStart.Time.Before(previousStart) {
+		return goal
+	}
+
+	tracked, err := s.trackedSeconds(ctx, goal, previousStart, previousEnd, false, now)
+	if err != nil {
+		log.Printf("GoalService: Failed to compute previous period of goal %s: %v", goal.ID, err)
+		return goal
+	}
+	if tracked > int64(goal.TargetMinutes)*60 {
+		return goal
+	}
+
+	return s.achieve(ctx, goal, previousStart, tracked)
+}
+
+// achieve marks the goal as met for the period and sends the achievement once
+func (s *GoalService) achieve(ctx context.Context, goal database.Goal, periodStart time.Time, tracked int64) database.Goal {
+	updated, err := s.queries.MarkGoalAchieved(ctx, database.MarkGoalAchievedParams{
+		PeriodStart: sql.NullTime{Time: periodStart, Valid: true},
+		ID:          goal.ID,
+	})
+	if err == sql.ErrNoRows {
+		// Already achieved in this period
+		return goal
+	}
+	if err != nil {
+		log.Printf("GoalService: Failed to mark goal %s achieved: %v", goal.ID, err)
+		return goal
+	}
+
+	if s.achievements == nil {
+		return updated
+	}
+
+	description := fmt.Sprintf("You tracked %d of %d minutes on %s this %s.", tracked/60, goal.TargetMinutes, goal.ScopeValue, goal.Period)
+	if goal.Direction == "at_most" {
+		description = fmt.Sprintf("You kept %s under %d minutes last %s.", goal.ScopeValue, goal.TargetMinutes, goal.Period)
+	}
+
+	_, err = s.achievements.Award(ctx, goal.UserID, earnedAchievement{
+		Code:        "goal",
+		Key:         fmt.Sprintf("%s:%s", goal.ID, periodStart.Format(time.RFC3339)),
+		Title:       "Goal Reached",
+		Description: description,
+		Payload: map[string]interface{}{
+			"goal_id":         goal.ID,
+			"scope":           goal.Scope,
+			"scope_value":     goal.ScopeValue,
+			"period":          goal.Period,
+			"period_start":    periodStart,
+			"direction":       goal.Direction,
+			"target_minutes":  goal.TargetMinutes,
+			"tracked_seconds": tracked,
+		},
+	})
+	if err != nil {
+		log.Printf("GoalService: Failed to award goal %s: %v", goal.ID, err)
+	}
+
+	return updated
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+type IdleService struct {
+	queries      *database.Queries
+	notify       func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	onTaskPaused func(task database.Task)
+}
+
+func NewIdleService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), onTaskPaused func(task database.Task)) *IdleService {
+	return &IdleService{
+		queries:      queries,
+		notify:       notify,
+		onTaskPaused: onTaskPaused,
+	}
+}
+
+func (s *IdleService) Tick(ctx context.Context) error {
+	rows, err := s.queries.GetIdleRunningTasks(ctx)
+	if err != nil {
+		log.Printf("IdleService: Failed to load idle running tasks: %v", err)
+		return err
+	}
+
+	for _, row := range rows {
+		if err := s.pauseTask(ctx, row.TaskID, row.LastActivityAt.Time); err != nil {
+			log.Printf("IdleService: Failed to pause task %s: %v", row.TaskID, err)
+			// Continue with other tasks
+			continue
+		}
+	}
+
+	return nil
+}
+
+// pauseTask stops the task as of the user's last activity and keeps the cut time
+// as a pending idle period the user can keep or discard
+func (s *IdleService) pauseTask(ctx context.Context, taskID uuid.UUID, lastActivity time.Time) error {
+	task, err := s.queries.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if !task.IsActive || task.IsCompleted {
+		return nil
+	}
+
+	now := time.Now()
+	stopMs := lastActivity.UnixMilli()
+	if task.ToggledAt.Valid && task.ToggledAt.Int64 > stopMs {
+		stopMs = task.ToggledAt.Int64
+	}
+	if stopMs > now.UnixMilli() {
+		return nil
+	}
+
+	tracked, err := taskTrackedSeconds(task, stopMs)
+	if err != nil {
+		return err
+	}
+	duration, err := durationIntToStr(tracked)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.queries.ToggleTask(ctx, database.ToggleTaskParams{
+		ID:             task.ID,
+		IsActive:       false,
+		ToggledAt:      sql.NullInt64{Valid: false},
+		Duration:       duration,
+		LastModifiedAt: now.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	recordTaskRevision(ctx, s.queries, uuid.NullUUID{}, "idle_pause", &task, updated)
+
+	if s.onTaskPaused != nil {
+		s.onTaskPaused(updated)
+	}
+
+	period, err := s.queries.CreateIdlePeriod(ctx, database.CreateIdlePeriodParams{
+		ID:        uuid.New(),
+		TaskID:    task.ID,
+		UserID:    task.UserID,
+		IdleFrom:  time.UnixMilli(stopMs),
+		IdleUntil: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	idleSeconds := int64(period.IdleUntil.Sub(period.IdleFrom).Seconds())
+	log.Printf("IdleService: Paused task %s after %d idle seconds", task.ID, idleSeconds)
+
+	if s.notify == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":           "idle",
+		"idle_period_id": period.ID,
+		"task_id":        task.ID,
+		"title":          task.Title,
+		"idle_from":      period.IdleFrom,
+		"idle_until":     period.IdleUntil,
+		"idle_seconds":   idleSeconds,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           task.UserID,
+		Title:            "Timer Paused While You Were Away",
+		Description:      sql.NullString{String: fmt.Sprintf("'%s' was paused after %d idle minutes. Keep or discard the idle time?", task.Title, idleSeconds/60), Valid: true},
+		Status:           "unseen",
+		NotificationType: "idle",
+		Payload:          payload,
+		Priority:         "normal",
+		ExpiresAt:        sql.NullTime{Time: now.Add(24 * time.Hour), Valid: true},
+		LastModifiedAt:   now.UnixMilli(),
+	})
+	return err
+}
@@ -18,12 +18,12 @@ func (q *Queries) CreateUserWithTiming(ctx context.Context, arg CreateUserParams
 	return q.CreateUser(ctx, arg)
 }
 
-func (q *Queries) GetActiveTaskByUUIDWithTiming(ctx context.Context, userID uuid.UUID) ([]Task, error) {
+func (q *Queries) GetActiveTaskByUUIDWithTiming(ctx context.Context, arg GetActiveTaskByUUIDParams) ([]Task, error) {
 	start := time.Now()
 	defer func() {
 		metrics.DatabaseQueryDuration.WithLabelValues("get_active_tasks").Observe(time.Since(start).Seconds())
 	}()
-	return q.GetActiveTaskByUUID(ctx, userID)
+	return q.GetActiveTaskByUUID(ctx, arg)
 }
 
 func (q *Queries) GetTasksDueForVisibilityWithTiming(ctx context.Context, userID uuid.UUID) ([]Task, error) {
@@ -300,3 +300,19 @@ func (q *Queries) ReleaseDueSnoozedNotificationsWithTiming(ctx context.Context,
 	}()
 	return q.ReleaseDueSnoozedNotifications(ctx, lastModifiedAt)
 }
+
+func (q *Queries) ListTaskRevisionsWithTiming(ctx context.Context, arg ListTaskRevisionsParams) ([]TaskRevision, error) {
+	start := time.Now()
+	defer func() {
+		metrics.DatabaseQueryDuration.WithLabelValues("list_task_revisions").Observe(time.Since(start).Seconds())
+	}()
+	return q.ListTaskRevisions(ctx, arg)
+}
+
+func (q *Queries) GetTimeReportWithTiming(ctx context.Context, arg GetTimeReportParams) ([]GetTimeReportRow, error) {
+	start := time.Now()
+	defer func() {
+		metrics.DatabaseQueryDuration.WithLabelValues("get_time_report").Observe(time.Since(start).Seconds())
+	}()
+	return q.GetTimeReport(ctx, arg)
+}
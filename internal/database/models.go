@@ -12,6 +12,56 @@ import (
 	"github.com/google/uuid"
 )
 
+type Achievement struct {
+	ID          uuid.UUID       `json:"id"`
+	UserID      uuid.UUID       `json:"user_id"`
+	Code        string          `json:"code"`
+	Key         string          `json:"key"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Payload     json.RawMessage `json:"payload"`
+	EarnedAt    time.Time       `json:"earned_at"`
+}
+
+type FocusSession struct {
+	ID              uuid.UUID    `json:"id"`
+	UserID          uuid.UUID    `json:"user_id"`
+	TaskID          uuid.UUID    `json:"task_id"`
+	Phase           string       `json:"phase"`
+	WorkMinutes     int32        `json:"work_minutes"`
+	BreakMinutes    int32        `json:"break_minutes"`
+	CyclesPlanned   int32        `json:"cycles_planned"`
+	CyclesCompleted int32        `json:"cycles_completed"`
+	PhaseEndsAt     time.Time    `json:"phase_ends_at"`
+	Status          string       `json:"status"`
+	StartedAt       time.Time    `json:"started_at"`
+	EndedAt         sql.NullTime `json:"ended_at"`
+}
+
+type Goal struct {
+	ID                      uuid.UUID    `json:"id"`
+	UserID                  uuid.UUID    `json:"user_id"`
+	Scope                   string       `json:"scope"`
+	ScopeValue              string       `json:"scope_value"`
+	Period                  string       `json:"period"`
+	TargetMinutes           int32        `json:"target_minutes"`
+	Direction               string       `json:"direction"`
+	LastAchievedPeriodStart sql.NullTime `json:"last_achieved_period_start"`
+	CreatedAt               time.Time    `json:"created_at"`
+	UpdatedAt               time.Time    `json:"updated_at"`
+}
+
+type IdlePeriod struct {
+	ID         uuid.UUID    `json:"id"`
+	TaskID     uuid.UUID    `json:"task_id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	IdleFrom   time.Time    `json:"idle_from"`
+	IdleUntil  time.Time    `json:"idle_until"`
+	Status     string       `json:"status"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ResolvedAt sql.NullTime `json:"resolved_at"`
+}
+
 type Notification struct {
 	ID               uuid.UUID       `json:"id"`
 	UserID           uuid.UUID       `json:"user_id"`
@@ -36,12 +86,14 @@ type NotificationJob struct {
 	ID            uuid.UUID       `json:"id"`
 	UserID        uuid.UUID       `json:"user_id"`
 	ScheduleID    uuid.NullUUID   `json:"schedule_id"`
-	OccurrenceID  uuid.UUID       `json:"occurrence_id"`
+	OccurrenceID  uuid.NullUUID   `json:"occurrence_id"`
 	OffsetMinutes int32           `json:"offset_minutes"`
 	PlannedSendAt time.Time       `json:"planned_send_at"`
 	SentAt        sql.NullTime    `json:"sent_at"`
 	CanceledAt    sql.NullTime    `json:"canceled_at"`
 	Payload       json.RawMessage `json:"payload"`
+	TaskID        uuid.NullUUID   `json:"task_id"`
+	ProjectID     uuid.NullUUID   `json:"project_id"`
 }
 
 type Occurrence struct {
@@ -51,6 +103,21 @@ type Occurrence struct {
 	Rev        int32     `json:"rev"`
 }
 
+type Project struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	Name              string         `json:"name"`
+	Description       string         `json:"description"`
+	Category          sql.NullString `json:"category"`
+	Deadline          sql.NullTime   `json:"deadline"`
+	Status            string         `json:"status"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	BudgetMinutes     sql.NullInt32  `json:"budget_minutes"`
+	BudgetWarnedAt    sql.NullTime   `json:"budget_warned_at"`
+	BudgetExhaustedAt sql.NullTime   `json:"budget_exhausted_at"`
+}
+
 type Schedule struct {
 	ID                    uuid.UUID      `json:"id"`
 	UserID                uuid.UUID      `json:"user_id"`
@@ -69,26 +136,64 @@ type Schedule struct {
 	CreatedAt             time.Time      `json:"created_at"`
 	UpdatedAt             time.Time      `json:"updated_at"`
 	Category              sql.NullString `json:"category"`
+	EstimateMinutes       sql.NullInt32  `json:"estimate_minutes"`
 }
 
 type Task struct {
-	ID                uuid.UUID     `json:"id"`
-	Title             string        `json:"title"`
-	Description       string        `json:"description"`
-	CreatedAt         time.Time     `json:"created_at"`
-	CompletedAt       sql.NullTime  `json:"completed_at"`
-	Duration          string        `json:"duration"`
-	Category          string        `json:"category"`
-	Tags              []string      `json:"tags"`
-	ToggledAt         sql.NullInt64 `json:"toggled_at"`
-	IsActive          bool          `json:"is_active"`
-	IsCompleted       bool          `json:"is_completed"`
-	UserID            uuid.UUID     `json:"user_id"`
-	LastModifiedAt    int64         `json:"last_modified_at"`
-	Priority          sql.NullInt32 `json:"priority"`
-	DueAt             sql.NullTime  `json:"due_at"`
-	ShowBeforeDueTime sql.NullInt32 `json:"show_before_due_time"`
-	VisibleFrom       sql.NullTime  `json:"visible_from"`
+	ID                     uuid.UUID     `json:"id"`
+	Title                  string        `json:"title"`
+	Description            string        `json:"description"`
+	CreatedAt              time.Time     `json:"created_at"`
+	CompletedAt            sql.NullTime  `json:"completed_at"`
+	Duration               string        `json:"duration"`
+	Category               string        `json:"category"`
+	Tags                   []string      `json:"tags"`
+	ToggledAt              sql.NullInt64 `json:"toggled_at"`
+	IsActive               bool          `json:"is_active"`
+	IsCompleted            bool          `json:"is_completed"`
+	UserID                 uuid.UUID     `json:"user_id"`
+	LastModifiedAt         int64         `json:"last_modified_at"`
+	Priority               sql.NullInt32 `json:"priority"`
+	DueAt                  sql.NullTime  `json:"due_at"`
+	ShowBeforeDueTime      sql.NullInt32 `json:"show_before_due_time"`
+	VisibleFrom            sql.NullTime  `json:"visible_from"`
+	Blocked                bool          `json:"blocked"`
+	SortPosition           float64       `json:"sort_position"`
+	EstimateMinutes        sql.NullInt32 `json:"estimate_minutes"`
+	EstimateWarnedAt       sql.NullTime  `json:"estimate_warned_at"`
+	EstimateExceededAt     sql.NullTime  `json:"estimate_exceeded_at"`
+	ContinuationOf         uuid.NullUUID `json:"continuation_of"`
+	FocusSessionsCompleted int32         `json:"focus_sessions_completed"`
+	ChecklistTotal         int32         `json:"checklist_total"`
+	ChecklistChecked       int32         `json:"checklist_checked"`
+	HiddenUntil            sql.NullTime  `json:"hidden_until"`
+	OverdueSince           sql.NullTime  `json:"overdue_since"`
+	OverdueNotifiedCount   int32         `json:"overdue_notified_count"`
+	StateID                uuid.NullUUID `json:"state_id"`
+	ProjectID              uuid.NullUUID `json:"project_id"`
+	BudgetMinutes          sql.NullInt32 `json:"budget_minutes"`
+	BudgetPolicy           string        `json:"budget_policy"`
+	BudgetWarnedAt         sql.NullTime  `json:"budget_warned_at"`
+	BudgetExhaustedAt      sql.NullTime  `json:"budget_exhausted_at"`
+}
+
+type TaskChecklistItem struct {
+	ID        uuid.UUID    `json:"id"`
+	TaskID    uuid.UUID    `json:"task_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Text      string       `json:"text"`
+	Checked   bool         `json:"checked"`
+	CheckedAt sql.NullTime `json:"checked_at"`
+	Position  float64      `json:"position"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type TaskDependency struct {
+	TaskID          uuid.UUID `json:"task_id"`
+	DependsOnTaskID uuid.UUID `json:"depends_on_task_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 type TaskLink struct {
@@ -96,14 +201,87 @@ type TaskLink struct {
 	TaskID       uuid.UUID `json:"task_id"`
 }
 
+type TaskNote struct {
+	ID        uuid.UUID      `json:"id"`
+	TaskID    uuid.UUID      `json:"task_id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	Body      string         `json:"body"`
+	DeviceSid uuid.NullUUID  `json:"device_sid"`
+	Device    sql.NullString `json:"device"`
+	CreatedAt time.Time      `json:"created_at"`
+	EditedAt  sql.NullTime   `json:"edited_at"`
+}
+
+type TaskRevision struct {
+	ID        uuid.UUID       `json:"id"`
+	TaskID    uuid.UUID       `json:"task_id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	ActorSid  uuid.NullUUID   `json:"actor_sid"`
+	Action    string          `json:"action"`
+	Changes   json.RawMessage `json:"changes"`
+	Snapshot  json.RawMessage `json:"snapshot"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type TaskSegment struct {
+	ID        uuid.UUID `json:"id"`
+	TaskID    uuid.UUID `json:"task_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	DayStart  time.Time `json:"day_start"`
+	EndedAt   time.Time `json:"ended_at"`
+	Duration  string    `json:"duration"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TimeAdjustment struct {
+	ID         uuid.UUID     `json:"id"`
+	TaskID     uuid.UUID     `json:"task_id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Kind       string        `json:"kind"`
+	Seconds    int64         `json:"seconds"`
+	StartedAt  sql.NullTime  `json:"started_at"`
+	EndedAt    sql.NullTime  `json:"ended_at"`
+	Reason     string        `json:"reason"`
+	RevertsID  uuid.NullUUID `json:"reverts_id"`
+	ActorSid   uuid.NullUUID `json:"actor_sid"`
+	CreatedAt  time.Time     `json:"created_at"`
+	RevertedAt sql.NullTime  `json:"reverted_at"`
+}
+
 type User struct {
-	ID          uuid.UUID      `json:"id"`
-	FirstName   string         `json:"first_name"`
-	LastName    string         `json:"last_name"`
-	Email       string         `json:"email"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	Categories  sql.NullString `json:"categories"`
-	KeyCommands sql.NullString `json:"key_commands"`
-	GoogleUid   sql.NullString `json:"google_uid"`
+	ID                       uuid.UUID      `json:"id"`
+	FirstName                string         `json:"first_name"`
+	LastName                 string         `json:"last_name"`
+	Email                    string         `json:"email"`
+	CreatedAt                time.Time      `json:"created_at"`
+	UpdatedAt                time.Time      `json:"updated_at"`
+	Categories               sql.NullString `json:"categories"`
+	KeyCommands              sql.NullString `json:"key_commands"`
+	GoogleUid                sql.NullString `json:"google_uid"`
+	Timezone                 string         `json:"timezone"`
+	RolloverHour             int32          `json:"rollover_hour"`
+	LastRolloverAt           time.Time      `json:"last_rollover_at"`
+	RolloverMode             string         `json:"rollover_mode"`
+	LastActivityAt           sql.NullTime   `json:"last_activity_at"`
+	IdleThresholdMinutes     int32          `json:"idle_threshold_minutes"`
+	WorkStartMinutes         sql.NullInt32  `json:"work_start_minutes"`
+	WorkEndMinutes           sql.NullInt32  `json:"work_end_minutes"`
+	WorkDays                 []int32        `json:"work_days"`
+	NudgeAfterMinutes        int32          `json:"nudge_after_minutes"`
+	NudgeIntervalMinutes     int32          `json:"nudge_interval_minutes"`
+	NothingRunningSince      sql.NullTime   `json:"nothing_running_since"`
+	LastNudgedAt             sql.NullTime   `json:"last_nudged_at"`
+	OverdueEscalationMinutes []int32        `json:"overdue_escalation_minutes"`
+	DayGapsNotify            bool           `json:"day_gaps_notify"`
+	DayGapsNotifiedOn        sql.NullTime   `json:"day_gaps_notified_on"`
+}
+
+type WorkflowState struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -27,6 +27,35 @@ func (q *Queries) CancelFutureJobsForSchedule(ctx context.Context, scheduleID uu
 	return err
 }
 
+const cancelPendingJobsForProject = `-- name: CancelPendingJobsForProject :exec
+UPDATE notification_jobs
+SET canceled_at = now()
+WHERE project_id = $1
+  AND sent_at IS NULL
+  AND canceled_at IS NULL
+`
+
+func (q *Queries) CancelPendingJobsForProject(ctx context.Context, projectID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, cancelPendingJobsForProject, projectID)
+	return err
+}
+
+const cancelPendingJobsForTask = `-- name: CancelPendingJobsForTask :exec
+UPDATE notification_jobs
+SET canceled_at = now()
+WHERE (
+    task_id = $1
+    OR occurrence_id IN (SELECT occurrence_id FROM task_links WHERE task_links.task_id = $1)
+  )
+  AND sent_at IS NULL
+  AND canceled_at IS NULL
+`
+
+func (q *Queries) CancelPendingJobsForTask(ctx context.Context, taskID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, cancelPendingJobsForTask, taskID)
+	return err
+}
+
 const claimDueNotificationJobs = `-- name: ClaimDueNotificationJobs :many
 WITH due AS (
   SELECT id
@@ -42,14 +71,15 @@ UPDATE notification_jobs j
 SET sent_at = now()
 FROM due
 WHERE j.id = due.id
-RETURNING j.id, j.user_id, j.schedule_id, j.occurrence_id, j.offset_minutes, j.payload, j.planned_send_at
+RETURNING j.id, j.user_id, j.schedule_id, j.occurrence_id, j.task_id, j.offset_minutes, j.payload, j.planned_send_at
 `
 
 type ClaimDueNotificationJobsRow struct {
 	ID            uuid.UUID       `json:"id"`
 	UserID        uuid.UUID       `json:"user_id"`
 	ScheduleID    uuid.NullUUID   `json:"schedule_id"`
-	OccurrenceID  uuid.UUID       `json:"occurrence_id"`
+	OccurrenceID  uuid.NullUUID   `json:"occurrence_id"`
+	TaskID        uuid.NullUUID   `json:"task_id"`
 	OffsetMinutes int32           `json:"offset_minutes"`
 	Payload       json.RawMessage `json:"payload"`
 	PlannedSendAt time.Time       `json:"planned_send_at"`
@@ -69,6 +99,7 @@ func (q *Queries) ClaimDueNotificationJobs(ctx context.Context, limit int32) ([]
 			&i.UserID,
 			&i.ScheduleID,
 			&i.OccurrenceID,
+			&i.TaskID,
 			&i.OffsetMinutes,
 			&i.Payload,
 			&i.PlannedSendAt,
@@ -87,7 +118,7 @@ func (q *Queries) ClaimDueNotificationJobs(ctx context.Context, limit int32) ([]
 }
 
 const getNotificationJobsByUser = `-- name: GetNotificationJobsByUser :many
-SELECT id, user_id, schedule_id, occurrence_id, offset_minutes, planned_send_at, sent_at, canceled_at, payload FROM notification_jobs 
+SELECT id, user_id, schedule_id, occurrence_id, offset_minutes, planned_send_at, sent_at, canceled_at, payload, task_id, project_id FROM notification_jobs 
 WHERE user_id = $1 
 ORDER BY planned_send_at ASC
 `
@@ -111,6 +142,8 @@ func (q *Queries) GetNotificationJobsByUser(ctx context.Context, userID uuid.UUI
 			&i.SentAt,
 			&i.CanceledAt,
 			&i.Payload,
+			&i.TaskID,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -126,7 +159,7 @@ func (q *Queries) GetNotificationJobsByUser(ctx context.Context, userID uuid.UUI
 }
 
 const getPendingNotificationJobs = `-- name: GetPendingNotificationJobs :many
-SELECT id, user_id, schedule_id, occurrence_id, offset_minutes, planned_send_at, sent_at, canceled_at, payload FROM notification_jobs 
+SELECT id, user_id, schedule_id, occurrence_id, offset_minutes, planned_send_at, sent_at, canceled_at, payload, task_id, project_id FROM notification_jobs 
 WHERE sent_at IS NULL 
   AND canceled_at IS NULL
 ORDER BY planned_send_at ASC
@@ -151,6 +184,8 @@ func (q *Queries) GetPendingNotificationJobs(ctx context.Context) ([]Notificatio
 			&i.SentAt,
 			&i.CanceledAt,
 			&i.Payload,
+			&i.TaskID,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -176,7 +211,7 @@ DO UPDATE SET planned_send_at = EXCLUDED.planned_send_at,
 type UpsertNotificationJobParams struct {
 	UserID        uuid.UUID       `json:"user_id"`
 	ScheduleID    uuid.NullUUID   `json:"schedule_id"`
-	OccurrenceID  uuid.UUID       `json:"occurrence_id"`
+	OccurrenceID  uuid.NullUUID   `json:"occurrence_id"`
 	OffsetMinutes int32           `json:"offset_minutes"`
 	PlannedSendAt time.Time       `json:"planned_send_at"`
 	Payload       json.RawMessage `json:"payload"`
@@ -193,3 +228,57 @@ func (q *Queries) UpsertNotificationJob(ctx context.Context, arg UpsertNotificat
 	)
 	return err
 }
+
+const upsertProjectNotificationJob = `-- name: UpsertProjectNotificationJob :exec
+INSERT INTO notification_jobs (user_id, project_id, offset_minutes, planned_send_at, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (project_id, offset_minutes) WHERE project_id IS NOT NULL AND sent_at IS NULL AND canceled_at IS NULL
+DO UPDATE SET planned_send_at = EXCLUDED.planned_send_at,
+              payload = EXCLUDED.payload
+`
+
+type UpsertProjectNotificationJobParams struct {
+	UserID        uuid.UUID       `json:"user_id"`
+	ProjectID     uuid.NullUUID   `json:"project_id"`
+	OffsetMinutes int32           `json:"offset_minutes"`
+	PlannedSendAt time.Time       `json:"planned_send_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) UpsertProjectNotificationJob(ctx context.Context, arg UpsertProjectNotificationJobParams) error {
+	_, err := q.db.ExecContext(ctx, upsertProjectNotificationJob,
+		arg.UserID,
+		arg.ProjectID,
+		arg.OffsetMinutes,
+		arg.PlannedSendAt,
+		arg.Payload,
+	)
+	return err
+}
+
+const upsertTaskNotificationJob = `-- name: UpsertTaskNotificationJob :exec
+INSERT INTO notification_jobs (user_id, task_id, offset_minutes, planned_send_at, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (task_id, offset_minutes) WHERE task_id IS NOT NULL AND sent_at IS NULL AND canceled_at IS NULL
+DO UPDATE SET planned_send_at = EXCLUDED.planned_send_at,
+              payload = EXCLUDED.payload
+`
+
+type UpsertTaskNotificationJobParams struct {
+	UserID        uuid.UUID       `json:"user_id"`
+	TaskID        uuid.NullUUID   `json:"task_id"`
+	OffsetMinutes int32           `json:"offset_minutes"`
+	PlannedSendAt time.Time       `json:"planned_send_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) UpsertTaskNotificationJob(ctx context.Context, arg UpsertTaskNotificationJobParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTaskNotificationJob,
+		arg.UserID,
+		arg.TaskID,
+		arg.OffsetMinutes,
+		arg.PlannedSendAt,
+		arg.Payload,
+	)
+	return err
+}
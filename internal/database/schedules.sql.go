@@ -16,23 +16,24 @@ import (
 
 const createSchedule = `-- name: CreateSchedule :one
 INSERT INTO schedules (user_id, kind, title, tz, start_local, rrule, until_local,
-                       show_before_minutes, notify_offsets_min, muted_offsets_min, category)
-VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, 0), COALESCE($9, '{2880,1440,720,360,180}')::integer[], COALESCE($10, '{}')::integer[], COALESCE($11, 'Life'))
-RETURNING id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category
+                       show_before_minutes, notify_offsets_min, muted_offsets_min, category, estimate_minutes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, 0), COALESCE($9, '{2880,1440,720,360,180}')::integer[], COALESCE($10, '{}')::integer[], COALESCE($11, 'Life'), $12)
+RETURNING id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category, estimate_minutes
 `
 
 type CreateScheduleParams struct {
-	UserID     uuid.UUID      `json:"user_id"`
-	Kind       string         `json:"kind"`
-	Title      string         `json:"title"`
-	Tz         string         `json:"tz"`
-	StartLocal time.Time      `json:"start_local"`
-	Rrule      sql.NullString `json:"rrule"`
-	UntilLocal sql.NullTime   `json:"until_local"`
-	Column8    interface{}    `json:"column_8"`
-	Column9    []int32        `json:"column_9"`
-	Column10   []int32        `json:"column_10"`
-	Column11   interface{}    `json:"column_11"`
+	UserID          uuid.UUID      `json:"user_id"`
+	Kind            string         `json:"kind"`
+	Title           string         `json:"title"`
+	Tz              string         `json:"tz"`
+	StartLocal      time.Time      `json:"start_local"`
+	Rrule           sql.NullString `json:"rrule"`
+	UntilLocal      sql.NullTime   `json:"until_local"`
+	Column8         interface{}    `json:"column_8"`
+	Column9         []int32        `json:"column_9"`
+	Column10        []int32        `json:"column_10"`
+	Column11        interface{}    `json:"column_11"`
+	EstimateMinutes sql.NullInt32  `json:"estimate_minutes"`
 }
 
 func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams) (Schedule, error) {
@@ -48,6 +49,7 @@ func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams)
 		pq.Array(arg.Column9),
 		pq.Array(arg.Column10),
 		arg.Column11,
+		arg.EstimateMinutes,
 	)
 	var i Schedule
 	err := row.Scan(
@@ -68,6 +70,7 @@ func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Category,
+		&i.EstimateMinutes,
 	)
 	return i, err
 }
@@ -91,7 +94,7 @@ func (q *Queries) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
 }
 
 const getActiveSchedules = `-- name: GetActiveSchedules :many
-SELECT id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category FROM schedules WHERE active = TRUE
+SELECT id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category, estimate_minutes FROM schedules WHERE active = TRUE
 `
 
 func (q *Queries) GetActiveSchedules(ctx context.Context) ([]Schedule, error) {
@@ -121,6 +124,7 @@ func (q *Queries) GetActiveSchedules(ctx context.Context) ([]Schedule, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Category,
+			&i.EstimateMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -136,7 +140,7 @@ func (q *Queries) GetActiveSchedules(ctx context.Context) ([]Schedule, error) {
 }
 
 const getScheduleByID = `-- name: GetScheduleByID :one
-SELECT id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category FROM schedules WHERE id = $1
+SELECT id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category, estimate_minutes FROM schedules WHERE id = $1
 `
 
 func (q *Queries) GetScheduleByID(ctx context.Context, id uuid.UUID) (Schedule, error) {
@@ -160,12 +164,13 @@ func (q *Queries) GetScheduleByID(ctx context.Context, id uuid.UUID) (Schedule,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Category,
+		&i.EstimateMinutes,
 	)
 	return i, err
 }
 
 const getSchedulesByUser = `-- name: GetSchedulesByUser :many
-SELECT id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category FROM schedules WHERE user_id = $1 AND active = TRUE ORDER BY created_at DESC
+SELECT id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category, estimate_minutes FROM schedules WHERE user_id = $1 AND active = TRUE ORDER BY created_at DESC
 `
 
 func (q *Queries) GetSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]Schedule, error) {
@@ -195,6 +200,7 @@ func (q *Queries) GetSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]S
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Category,
+			&i.EstimateMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -209,6 +215,41 @@ func (q *Queries) GetSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]S
 	return items, nil
 }
 
+const getTaskScheduleOffsets = `-- name: GetTaskScheduleOffsets :one
+-- The schedule a task came from, walking back through rollover clones to the linked occurrence
+WITH RECURSIVE lineage AS (
+    SELECT t.id, t.continuation_of, 0 AS depth
+    FROM tasks t
+    WHERE t.id = $1
+    UNION ALL
+    SELECT p.id, p.continuation_of, lineage.depth + 1
+    FROM lineage
+    JOIN tasks p ON p.id = lineage.continuation_of
+)
+SELECT s.notify_offsets_min, s.muted_offsets_min
+FROM lineage
+JOIN task_links l ON l.task_id = lineage.id
+JOIN occurrences o ON o.id = l.occurrence_id
+JOIN schedules s ON s.id = o.schedule_id
+ORDER BY lineage.depth
+LIMIT 1
+`
+
+type GetTaskScheduleOffsetsRow struct {
+	NotifyOffsetsMin []int32 `json:"notify_offsets_min"`
+	MutedOffsetsMin  []int32 `json:"muted_offsets_min"`
+}
+
+func (q *Queries) GetTaskScheduleOffsets(ctx context.Context, id uuid.UUID) (GetTaskScheduleOffsetsRow, error) {
+	row := q.db.QueryRowContext(ctx, getTaskScheduleOffsets, id)
+	var i GetTaskScheduleOffsetsRow
+	err := row.Scan(
+		pq.Array(&i.NotifyOffsetsMin),
+		pq.Array(&i.MutedOffsetsMin),
+	)
+	return i, err
+}
+
 const incrementScheduleRev = `-- name: IncrementScheduleRev :exec
 UPDATE schedules SET rev = rev + 1, updated_at = NOW() WHERE id = $1
 `
@@ -236,9 +277,9 @@ const updateSchedule = `-- name: UpdateSchedule :one
 UPDATE schedules 
 SET title = $2, tz = $3, start_local = $4, rrule = $5, until_local = $6,
     show_before_minutes = $7, notify_offsets_min = $8, muted_offsets_min = $9,
-    category = $10, updated_at = NOW()
+    category = $10, estimate_minutes = $11, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category
+RETURNING id, user_id, kind, title, tz, start_local, rrule, until_local, show_before_minutes, notify_offsets_min, muted_offsets_min, active, rev, last_materialized_until, created_at, updated_at, category, estimate_minutes
 `
 
 type UpdateScheduleParams struct {
@@ -252,6 +293,7 @@ type UpdateScheduleParams struct {
 	NotifyOffsetsMin  []int32        `json:"notify_offsets_min"`
 	MutedOffsetsMin   []int32        `json:"muted_offsets_min"`
 	Category          sql.NullString `json:"category"`
+	EstimateMinutes   sql.NullInt32  `json:"estimate_minutes"`
 }
 
 func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams) (Schedule, error) {
@@ -266,6 +308,7 @@ func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams)
 		pq.Array(arg.NotifyOffsetsMin),
 		pq.Array(arg.MutedOffsetsMin),
 		arg.Category,
+		arg.EstimateMinutes,
 	)
 	var i Schedule
 	err := row.Scan(
@@ -286,6 +329,7 @@ func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Category,
+		&i.EstimateMinutes,
 	)
 	return i, err
 }
@@ -14,6 +14,223 @@ import (
 	"github.com/lib/pq"
 )
 
+const clearStaleOverdue = `-- name: ClearStaleOverdue :many
+UPDATE tasks
+SET
+	overdue_since = NULL,
+	overdue_notified_count = 0,
+	last_modified_at = $1
+WHERE overdue_since IS NOT NULL
+	AND is_completed = FALSE
+	AND (due_at IS NULL OR due_at > NOW() OR due_at <> overdue_since)
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+func (q *Queries) ClearStaleOverdue(ctx context.Context, lastModifiedAt int64) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, clearStaleOverdue, lastModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearTasksProject = `-- name: ClearTasksProject :many
+UPDATE tasks
+SET
+	project_id = NULL,
+	last_modified_at = $2
+WHERE project_id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type ClearTasksProjectParams struct {
+	ProjectID      uuid.NullUUID `json:"project_id"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) ClearTasksProject(ctx context.Context, arg ClearTasksProjectParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, clearTasksProject, arg.ProjectID, arg.LastModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearTasksState = `-- name: ClearTasksState :many
+UPDATE tasks
+SET
+	state_id = NULL,
+	last_modified_at = $2
+WHERE state_id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type ClearTasksStateParams struct {
+	StateID        uuid.NullUUID `json:"state_id"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) ClearTasksState(ctx context.Context, arg ClearTasksStateParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, clearTasksState, arg.StateID, arg.LastModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const completeTask = `-- name: CompleteTask :one
 UPDATE tasks
 SET
@@ -23,7 +240,7 @@ SET
 	completed_at = $3,
 	last_modified_at = $4
 WHERE id = $1
-RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
 `
 
 type CompleteTaskParams struct {
@@ -59,10 +276,63 @@ func (q *Queries) CompleteTask(ctx context.Context, arg CompleteTaskParams) (Tas
 		&i.DueAt,
 		&i.ShowBeforeDueTime,
 		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
 	)
 	return i, err
 }
 
+const copyTaskBudget = `-- name: CopyTaskBudget :exec
+UPDATE tasks t
+SET
+	budget_minutes = s.budget_minutes,
+	budget_policy = s.budget_policy,
+	budget_warned_at = s.budget_warned_at,
+	budget_exhausted_at = s.budget_exhausted_at
+FROM tasks s
+WHERE t.id = $1 AND s.id = $2
+`
+
+type CopyTaskBudgetParams struct {
+	ID       uuid.UUID `json:"id"`
+	SourceID uuid.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyTaskBudget(ctx context.Context, arg CopyTaskBudgetParams) error {
+	_, err := q.db.ExecContext(ctx, copyTaskBudget, arg.ID, arg.SourceID)
+	return err
+}
+
+const countOverdueTasksByUser = `-- name: CountOverdueTasksByUser :one
+SELECT COUNT(*)
+FROM tasks
+WHERE user_id = $1 AND is_completed = FALSE AND overdue_since IS NOT NULL
+	AND (hidden_until IS NULL OR hidden_until <= NOW())
+`
+
+func (q *Queries) CountOverdueTasksByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOverdueTasksByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTask = `-- name: CreateTask :one
 INSERT INTO tasks (
 	id,
@@ -80,7 +350,10 @@ INSERT INTO tasks (
 	last_modified_at,
 	priority,
 	due_at,
-	show_before_due_time
+	show_before_due_time,
+	sort_position,
+	estimate_minutes,
+	continuation_of
 ) VALUES (
 	$1,
 	$2,
@@ -97,8 +370,11 @@ INSERT INTO tasks (
 	$13,
 	$14,
 	$15,
-	$16
-) RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from
+	$16,
+	COALESCE((SELECT MAX(sort_position) FROM tasks WHERE user_id = $12 AND is_completed = FALSE), 0) + 1024,
+	$17,
+	$18
+) RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
 `
 
 type CreateTaskParams struct {
@@ -118,6 +394,8 @@ type CreateTaskParams struct {
 	Priority          sql.NullInt32 `json:"priority"`
 	DueAt             sql.NullTime  `json:"due_at"`
 	ShowBeforeDueTime sql.NullInt32 `json:"show_before_due_time"`
+	EstimateMinutes   sql.NullInt32 `json:"estimate_minutes"`
+	ContinuationOf    uuid.NullUUID `json:"continuation_of"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
@@ -138,6 +416,8 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		arg.Priority,
 		arg.DueAt,
 		arg.ShowBeforeDueTime,
+		arg.EstimateMinutes,
+		arg.ContinuationOf,
 	)
 	var i Task
 	err := row.Scan(
@@ -158,6 +438,24 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.DueAt,
 		&i.ShowBeforeDueTime,
 		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
 	)
 	return i, err
 }
@@ -182,9 +480,12 @@ SET
 	last_modified_at = $6,
 	priority = $7,
 	due_at = $8,
-	show_before_due_time = $9
+	show_before_due_time = $9,
+	estimate_minutes = $10,
+	estimate_warned_at = CASE WHEN estimate_minutes IS DISTINCT FROM $10 THEN NULL ELSE estimate_warned_at END,
+	estimate_exceeded_at = CASE WHEN estimate_minutes IS DISTINCT FROM $10 THEN NULL ELSE estimate_exceeded_at END
 WHERE id = $1
-RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
 `
 
 type EditTaskParams struct {
@@ -197,6 +498,7 @@ type EditTaskParams struct {
 	Priority          sql.NullInt32 `json:"priority"`
 	DueAt             sql.NullTime  `json:"due_at"`
 	ShowBeforeDueTime sql.NullInt32 `json:"show_before_due_time"`
+	EstimateMinutes   sql.NullInt32 `json:"estimate_minutes"`
 }
 
 func (q *Queries) EditTask(ctx context.Context, arg EditTaskParams) (Task, error) {
@@ -210,6 +512,7 @@ func (q *Queries) EditTask(ctx context.Context, arg EditTaskParams) (Task, error
 		arg.Priority,
 		arg.DueAt,
 		arg.ShowBeforeDueTime,
+		arg.EstimateMinutes,
 	)
 	var i Task
 	err := row.Scan(
@@ -230,19 +533,50 @@ func (q *Queries) EditTask(ctx context.Context, arg EditTaskParams) (Task, error
 		&i.DueAt,
 		&i.ShowBeforeDueTime,
 		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
 	)
 	return i, err
 }
 
 const getActiveTaskByUUID = `-- name: GetActiveTaskByUUID :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from 
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at 
 FROM tasks
 WHERE user_id = $1 AND is_completed = FALSE
-ORDER BY created_at ASC
+	AND (hidden_until IS NULL OR hidden_until <= NOW())
+	AND (
+		$2::boolean IS NULL OR (overdue_since IS NOT NULL) = $2::boolean
+	)
+	AND (
+		$3::uuid IS NULL OR state_id = $3::uuid
+	)
+ORDER BY sort_position ASC, created_at ASC
 `
 
-func (q *Queries) GetActiveTaskByUUID(ctx context.Context, userID uuid.UUID) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getActiveTaskByUUID, userID)
+type GetActiveTaskByUUIDParams struct {
+	UserID  uuid.UUID     `json:"user_id"`
+	Overdue sql.NullBool  `json:"overdue"`
+	StateID uuid.NullUUID `json:"state_id"`
+}
+
+func (q *Queries) GetActiveTaskByUUID(ctx context.Context, arg GetActiveTaskByUUIDParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveTaskByUUID, arg.UserID, arg.Overdue, arg.StateID)
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +602,24 @@ func (q *Queries) GetActiveTaskByUUID(ctx context.Context, userID uuid.UUID) ([]
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -283,7 +635,7 @@ func (q *Queries) GetActiveTaskByUUID(ctx context.Context, userID uuid.UUID) ([]
 }
 
 const getCompletedTasksByUUID = `-- name: GetCompletedTasksByUUID :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from 
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at 
 FROM tasks
 WHERE user_id = $1
 	AND is_completed = TRUE
@@ -303,10 +655,20 @@ WHERE user_id = $1
 	)
 	  AND (
 		$5::text IS NULL OR title ILIKE $5::text
+		OR EXISTS (
+			SELECT 1 FROM task_notes n
+			WHERE n.task_id = tasks.id AND n.body ILIKE $5::text
+		)
 	  )
 	  AND (
 		$6::text IS NULL OR category = $6::text
 	  )
+	  AND (
+		$7::boolean IS NULL OR (overdue_since IS NOT NULL) = $7::boolean
+	  )
+	  AND (
+		$8::uuid IS NULL OR state_id = $8::uuid
+	  )
 ORDER BY created_at ASC
 `
 
@@ -317,6 +679,8 @@ type GetCompletedTasksByUUIDParams struct {
 	Tags        []string       `json:"tags"`
 	SearchQuery sql.NullString `json:"search_query"`
 	Category    sql.NullString `json:"category"`
+	Overdue     sql.NullBool   `json:"overdue"`
+	StateID     uuid.NullUUID  `json:"state_id"`
 }
 
 func (q *Queries) GetCompletedTasksByUUID(ctx context.Context, arg GetCompletedTasksByUUIDParams) ([]Task, error) {
@@ -327,6 +691,8 @@ func (q *Queries) GetCompletedTasksByUUID(ctx context.Context, arg GetCompletedT
 		pq.Array(arg.Tags),
 		arg.SearchQuery,
 		arg.Category,
+		arg.Overdue,
+		arg.StateID,
 	)
 	if err != nil {
 		return nil, err
@@ -353,6 +719,24 @@ func (q *Queries) GetCompletedTasksByUUID(ctx context.Context, arg GetCompletedT
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -368,7 +752,7 @@ func (q *Queries) GetCompletedTasksByUUID(ctx context.Context, arg GetCompletedT
 }
 
 const getNonCompletedTasks = `-- name: GetNonCompletedTasks :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
 FROM tasks
 WHERE is_completed = FALSE
 ORDER BY user_id
@@ -401,6 +785,24 @@ func (q *Queries) GetNonCompletedTasks(ctx context.Context) ([]Task, error) {
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -415,41 +817,15 @@ func (q *Queries) GetNonCompletedTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
-const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from FROM tasks WHERE id = $1
-`
-
-func (q *Queries) GetTaskByID(ctx context.Context, id uuid.UUID) (Task, error) {
-	row := q.db.QueryRowContext(ctx, getTaskByID, id)
-	var i Task
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.CreatedAt,
-		&i.CompletedAt,
-		&i.Duration,
-		&i.Category,
-		pq.Array(&i.Tags),
-		&i.ToggledAt,
-		&i.IsActive,
-		&i.IsCompleted,
-		&i.UserID,
-		&i.LastModifiedAt,
-		&i.Priority,
-		&i.DueAt,
-		&i.ShowBeforeDueTime,
-		&i.VisibleFrom,
-	)
-	return i, err
-}
-
-const getTasks = `-- name: GetTasks :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from FROM tasks ORDER BY created_at ASC
+const getOpenTasksByUser = `-- name: GetOpenTasksByUser :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+FROM tasks
+WHERE user_id = $1 AND is_completed = FALSE
+ORDER BY sort_position ASC, created_at ASC
 `
 
-func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getTasks)
+func (q *Queries) GetOpenTasksByUser(ctx context.Context, userID uuid.UUID) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getOpenTasksByUser, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -475,6 +851,24 @@ func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -489,19 +883,15 @@ func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
-const getTasksDueForNotifications = `-- name: GetTasksDueForNotifications :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from 
+const getOverdueTasksByUser = `-- name: GetOverdueTasksByUser :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
 FROM tasks
-WHERE user_id = $1 
-  AND is_completed = FALSE
-  AND due_at IS NOT NULL
-  AND due_at > NOW() AT TIME ZONE 'UTC'
-  AND due_at <= NOW() AT TIME ZONE 'UTC' + INTERVAL '48 hours'
-ORDER BY due_at ASC
+WHERE user_id = $1 AND is_completed = FALSE AND overdue_since IS NOT NULL
+ORDER BY overdue_since ASC
 `
 
-func (q *Queries) GetTasksDueForNotifications(ctx context.Context, userID uuid.UUID) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getTasksDueForNotifications, userID)
+func (q *Queries) GetOverdueTasksByUser(ctx context.Context, userID uuid.UUID) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getOverdueTasksByUser, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -527,6 +917,24 @@ func (q *Queries) GetTasksDueForNotifications(ctx context.Context, userID uuid.U
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -541,20 +949,18 @@ func (q *Queries) GetTasksDueForNotifications(ctx context.Context, userID uuid.U
 	return items, nil
 }
 
-const getTasksDueForVisibility = `-- name: GetTasksDueForVisibility :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from 
-FROM tasks
-WHERE user_id = $1 
-  AND is_completed = FALSE
-  AND due_at IS NOT NULL
-  AND show_before_due_time IS NOT NULL
-  AND due_at - INTERVAL '1 minute' * show_before_due_time <= NOW() AT TIME ZONE 'UTC'
-  AND due_at - INTERVAL '1 minute' * show_before_due_time > NOW() AT TIME ZONE 'UTC' - INTERVAL '1 minute'
-ORDER BY due_at ASC
+const getRunningBudgetedTasks = `-- name: GetRunningBudgetedTasks :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at FROM tasks
+WHERE is_active = TRUE
+	AND is_completed = FALSE
+	AND (
+		budget_minutes IS NOT NULL
+		OR project_id IN (SELECT id FROM projects WHERE budget_minutes IS NOT NULL)
+	)
 `
 
-func (q *Queries) GetTasksDueForVisibility(ctx context.Context, userID uuid.UUID) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getTasksDueForVisibility, userID)
+func (q *Queries) GetRunningBudgetedTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getRunningBudgetedTasks)
 	if err != nil {
 		return nil, err
 	}
@@ -580,6 +986,24 @@ func (q *Queries) GetTasksDueForVisibility(ctx context.Context, userID uuid.UUID
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -594,19 +1018,44 @@ func (q *Queries) GetTasksDueForVisibility(ctx context.Context, userID uuid.UUID
 	return items, nil
 }
 
-const getTasksDueForVisibilityAll = `-- name: GetTasksDueForVisibilityAll :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from 
-FROM tasks
-WHERE is_completed = FALSE
-  AND due_at IS NOT NULL
-  AND show_before_due_time IS NOT NULL
-  AND due_at - INTERVAL '1 minute' * show_before_due_time <= NOW() AT TIME ZONE 'UTC'
-  AND due_at - INTERVAL '1 minute' * show_before_due_time > NOW() AT TIME ZONE 'UTC' - INTERVAL '1 minute'
-ORDER BY due_at ASC
+const getRunningTaskIDsByUser = `-- name: GetRunningTaskIDsByUser :many
+SELECT id FROM tasks
+WHERE user_id = $1 AND is_active = TRUE AND is_completed = FALSE
 `
 
-func (q *Queries) GetTasksDueForVisibilityAll(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getTasksDueForVisibilityAll)
+func (q *Queries) GetRunningTaskIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getRunningTaskIDsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRunningTasksWithEstimate = `-- name: GetRunningTasksWithEstimate :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at FROM tasks
+WHERE is_active = TRUE
+  AND is_completed = FALSE
+  AND estimate_minutes IS NOT NULL
+  AND estimate_exceeded_at IS NULL
+`
+
+func (q *Queries) GetRunningTasksWithEstimate(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getRunningTasksWithEstimate)
 	if err != nil {
 		return nil, err
 	}
@@ -632,6 +1081,24 @@ func (q *Queries) GetTasksDueForVisibilityAll(ctx context.Context) ([]Task, erro
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -646,18 +1113,346 @@ func (q *Queries) GetTasksDueForVisibilityAll(ctx context.Context) ([]Task, erro
 	return items, nil
 }
 
-const getUpcomingTasksForNotifications = `-- name: GetUpcomingTasksForNotifications :many
-SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from 
-FROM tasks
-WHERE is_completed = FALSE
-  AND due_at IS NOT NULL
-  AND due_at > NOW() AT TIME ZONE 'UTC'
-  AND due_at <= NOW() AT TIME ZONE 'UTC' + INTERVAL '48 hours'
-ORDER BY due_at ASC
+const getTaskByID = `-- name: GetTaskByID :one
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at FROM tasks WHERE id = $1
 `
 
-func (q *Queries) GetUpcomingTasksForNotifications(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getUpcomingTasksForNotifications)
+func (q *Queries) GetTaskByID(ctx context.Context, id uuid.UUID) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTaskByID, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const getTaskByIDForUpdate = `-- name: GetTaskByIDForUpdate :one
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at FROM tasks WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) GetTaskByIDForUpdate(ctx context.Context, id uuid.UUID) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTaskByIDForUpdate, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const getTaskEarlierTrackedSeconds = `-- name: GetTaskEarlierTrackedSeconds :one
+WITH RECURSIVE chain AS (
+	SELECT t.id, t.continuation_of FROM tasks t WHERE t.id = $1
+	UNION ALL
+	SELECT p.id, p.continuation_of FROM tasks p JOIN chain c ON p.id = c.continuation_of
+)
+SELECT (
+	COALESCE((
+		SELECT SUM(EXTRACT(EPOCH FROM p.duration::interval))
+		FROM tasks p
+		WHERE p.id IN (SELECT id FROM chain) AND p.id <> $1
+	), 0)
+	+ COALESCE((
+		SELECT SUM(EXTRACT(EPOCH FROM s.duration::interval))
+		FROM task_segments s
+		WHERE s.task_id IN (SELECT id FROM chain)
+	), 0)
+)::bigint AS tracked_seconds
+`
+
+func (q *Queries) GetTaskEarlierTrackedSeconds(ctx context.Context, taskID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getTaskEarlierTrackedSeconds, taskID)
+	var tracked_seconds int64
+	err := row.Scan(&tracked_seconds)
+	return tracked_seconds, err
+}
+
+const getTasks = `-- name: GetTasks :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at FROM tasks ORDER BY created_at ASC
+`
+
+func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksDueForNotifications = `-- name: GetTasksDueForNotifications :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at 
+FROM tasks
+WHERE user_id = $1 
+  AND is_completed = FALSE
+  AND due_at IS NOT NULL
+  AND due_at > NOW() AT TIME ZONE 'UTC'
+  AND due_at <= NOW() AT TIME ZONE 'UTC' + INTERVAL '48 hours'
+ORDER BY due_at ASC
+`
+
+func (q *Queries) GetTasksDueForNotifications(ctx context.Context, userID uuid.UUID) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getTasksDueForNotifications, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksDueForVisibility = `-- name: GetTasksDueForVisibility :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at 
+FROM tasks
+WHERE user_id = $1 
+  AND is_completed = FALSE
+  AND due_at IS NOT NULL
+  AND show_before_due_time IS NOT NULL
+  AND due_at - INTERVAL '1 minute' * show_before_due_time <= NOW() AT TIME ZONE 'UTC'
+  AND due_at - INTERVAL '1 minute' * show_before_due_time > NOW() AT TIME ZONE 'UTC' - INTERVAL '1 minute'
+  AND (hidden_until IS NULL OR hidden_until <= NOW())
+ORDER BY due_at ASC
+`
+
+func (q *Queries) GetTasksDueForVisibility(ctx context.Context, userID uuid.UUID) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getTasksDueForVisibility, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksDueForVisibilityAll = `-- name: GetTasksDueForVisibilityAll :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at 
+FROM tasks
+WHERE is_completed = FALSE
+  AND due_at IS NOT NULL
+  AND show_before_due_time IS NOT NULL
+  AND due_at - INTERVAL '1 minute' * show_before_due_time <= NOW() AT TIME ZONE 'UTC'
+  AND due_at - INTERVAL '1 minute' * show_before_due_time > NOW() AT TIME ZONE 'UTC' - INTERVAL '1 minute'
+  AND (hidden_until IS NULL OR hidden_until <= NOW())
+ORDER BY due_at ASC
+`
+
+func (q *Queries) GetTasksDueForVisibilityAll(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getTasksDueForVisibilityAll)
 	if err != nil {
 		return nil, err
 	}
@@ -683,6 +1478,24 @@ func (q *Queries) GetUpcomingTasksForNotifications(ctx context.Context) ([]Task,
 			&i.DueAt,
 			&i.ShowBeforeDueTime,
 			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -697,6 +1510,1024 @@ func (q *Queries) GetUpcomingTasksForNotifications(ctx context.Context) ([]Task,
 	return items, nil
 }
 
+const getUpcomingTasksForNotifications = `-- name: GetUpcomingTasksForNotifications :many
+SELECT id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at 
+FROM tasks
+WHERE is_completed = FALSE
+  AND due_at IS NOT NULL
+  AND due_at > NOW() AT TIME ZONE 'UTC'
+  AND due_at <= NOW() AT TIME ZONE 'UTC' + INTERVAL '48 hours'
+ORDER BY due_at ASC
+`
+
+func (q *Queries) GetUpcomingTasksForNotifications(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getUpcomingTasksForNotifications)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasActiveTask = `-- name: HasActiveTask :one
+SELECT EXISTS (
+	SELECT 1 FROM tasks
+	WHERE user_id = $1 AND is_active = TRUE AND is_completed = FALSE
+) AS exists
+`
+
+func (q *Queries) HasActiveTask(ctx context.Context, userID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasActiveTask, userID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const incrementTaskFocusSessions = `-- name: IncrementTaskFocusSessions :one
+UPDATE tasks
+SET
+	focus_sessions_completed = focus_sessions_completed + 1,
+	last_modified_at = $2
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type IncrementTaskFocusSessionsParams struct {
+	ID             uuid.UUID `json:"id"`
+	LastModifiedAt int64     `json:"last_modified_at"`
+}
+
+func (q *Queries) IncrementTaskFocusSessions(ctx context.Context, arg IncrementTaskFocusSessionsParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, incrementTaskFocusSessions, arg.ID, arg.LastModifiedAt)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const markTaskBudgetExhausted = `-- name: MarkTaskBudgetExhausted :exec
+UPDATE tasks
+SET
+	budget_warned_at = COALESCE(budget_warned_at, $2),
+	budget_exhausted_at = COALESCE(budget_exhausted_at, $2)
+WHERE id = $1
+`
+
+type MarkTaskBudgetExhaustedParams struct {
+	ID                uuid.UUID    `json:"id"`
+	BudgetExhaustedAt sql.NullTime `json:"budget_exhausted_at"`
+}
+
+func (q *Queries) MarkTaskBudgetExhausted(ctx context.Context, arg MarkTaskBudgetExhaustedParams) error {
+	_, err := q.db.ExecContext(ctx, markTaskBudgetExhausted, arg.ID, arg.BudgetExhaustedAt)
+	return err
+}
+
+const markTaskBudgetWarned = `-- name: MarkTaskBudgetWarned :exec
+UPDATE tasks
+SET budget_warned_at = COALESCE(budget_warned_at, $2)
+WHERE id = $1
+`
+
+type MarkTaskBudgetWarnedParams struct {
+	ID             uuid.UUID    `json:"id"`
+	BudgetWarnedAt sql.NullTime `json:"budget_warned_at"`
+}
+
+func (q *Queries) MarkTaskBudgetWarned(ctx context.Context, arg MarkTaskBudgetWarnedParams) error {
+	_, err := q.db.ExecContext(ctx, markTaskBudgetWarned, arg.ID, arg.BudgetWarnedAt)
+	return err
+}
+
+const markTaskEstimateExceeded = `-- name: MarkTaskEstimateExceeded :one
+UPDATE tasks
+SET
+	estimate_warned_at = COALESCE(estimate_warned_at, NOW()),
+	estimate_exceeded_at = NOW(),
+	last_modified_at = $2
+WHERE id = $1 AND estimate_exceeded_at IS NULL
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type MarkTaskEstimateExceededParams struct {
+	ID             uuid.UUID `json:"id"`
+	LastModifiedAt int64     `json:"last_modified_at"`
+}
+
+func (q *Queries) MarkTaskEstimateExceeded(ctx context.Context, arg MarkTaskEstimateExceededParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, markTaskEstimateExceeded, arg.ID, arg.LastModifiedAt)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const markTaskEstimateWarned = `-- name: MarkTaskEstimateWarned :one
+UPDATE tasks
+SET
+	estimate_warned_at = NOW(),
+	last_modified_at = $2
+WHERE id = $1 AND estimate_warned_at IS NULL
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type MarkTaskEstimateWarnedParams struct {
+	ID             uuid.UUID `json:"id"`
+	LastModifiedAt int64     `json:"last_modified_at"`
+}
+
+func (q *Queries) MarkTaskEstimateWarned(ctx context.Context, arg MarkTaskEstimateWarnedParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, markTaskEstimateWarned, arg.ID, arg.LastModifiedAt)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const markTasksOverdue = `-- name: MarkTasksOverdue :many
+UPDATE tasks
+SET
+	overdue_since = due_at,
+	overdue_notified_count = 0,
+	last_modified_at = $1
+WHERE overdue_since IS NULL
+	AND is_completed = FALSE
+	AND due_at IS NOT NULL
+	AND due_at <= NOW()
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+func (q *Queries) MarkTasksOverdue(ctx context.Context, lastModifiedAt int64) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, markTasksOverdue, lastModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeTask = `-- name: MergeTask :one
+UPDATE tasks
+SET
+	created_at = $2,
+	duration = $3,
+	is_active = $4,
+	toggled_at = $5,
+	last_modified_at = $6
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type MergeTaskParams struct {
+	ID             uuid.UUID     `json:"id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Duration       string        `json:"duration"`
+	IsActive       bool          `json:"is_active"`
+	ToggledAt      sql.NullInt64 `json:"toggled_at"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) MergeTask(ctx context.Context, arg MergeTaskParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, mergeTask,
+		arg.ID,
+		arg.CreatedAt,
+		arg.Duration,
+		arg.IsActive,
+		arg.ToggledAt,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const releaseExpiredDeferrals = `-- name: ReleaseExpiredDeferrals :many
+UPDATE tasks
+SET
+	hidden_until = NULL,
+	last_modified_at = $1
+WHERE hidden_until <= NOW() AND is_completed = FALSE
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+func (q *Queries) ReleaseExpiredDeferrals(ctx context.Context, lastModifiedAt int64) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, releaseExpiredDeferrals, lastModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.Duration,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.ToggledAt,
+			&i.IsActive,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.LastModifiedAt,
+			&i.Priority,
+			&i.DueAt,
+			&i.ShowBeforeDueTime,
+			&i.VisibleFrom,
+			&i.Blocked,
+			&i.SortPosition,
+			&i.EstimateMinutes,
+			&i.EstimateWarnedAt,
+			&i.EstimateExceededAt,
+			&i.ContinuationOf,
+			&i.FocusSessionsCompleted,
+			&i.ChecklistTotal,
+			&i.ChecklistChecked,
+			&i.HiddenUntil,
+			&i.OverdueSince,
+			&i.OverdueNotifiedCount,
+			&i.StateID,
+			&i.ProjectID,
+			&i.BudgetMinutes,
+			&i.BudgetPolicy,
+			&i.BudgetWarnedAt,
+			&i.BudgetExhaustedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const renumberTaskSortPositions = `-- name: RenumberTaskSortPositions :exec
+UPDATE tasks
+SET sort_position = ranked.position
+FROM (
+	SELECT id, ROW_NUMBER() OVER (ORDER BY sort_position ASC, created_at ASC) * 1024 AS position
+	FROM tasks
+	WHERE user_id = $1 AND is_completed = FALSE
+) ranked
+WHERE tasks.id = ranked.id
+`
+
+func (q *Queries) RenumberTaskSortPositions(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, renumberTaskSortPositions, userID)
+	return err
+}
+
+const resetTaskDay = `-- name: ResetTaskDay :one
+UPDATE tasks
+SET
+	duration = '00:00:00',
+	toggled_at = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type ResetTaskDayParams struct {
+	ID             uuid.UUID     `json:"id"`
+	ToggledAt      sql.NullInt64 `json:"toggled_at"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) ResetTaskDay(ctx context.Context, arg ResetTaskDayParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, resetTaskDay,
+		arg.ID,
+		arg.ToggledAt,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const setTaskBudget = `-- name: SetTaskBudget :one
+UPDATE tasks
+SET
+	budget_minutes = $2,
+	budget_policy = $3,
+	budget_warned_at = CASE WHEN budget_minutes IS DISTINCT FROM $2 THEN NULL ELSE budget_warned_at END,
+	budget_exhausted_at = CASE WHEN budget_minutes IS DISTINCT FROM $2 THEN NULL ELSE budget_exhausted_at END,
+	last_modified_at = $4
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type SetTaskBudgetParams struct {
+	ID             uuid.UUID     `json:"id"`
+	BudgetMinutes  sql.NullInt32 `json:"budget_minutes"`
+	BudgetPolicy   string        `json:"budget_policy"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) SetTaskBudget(ctx context.Context, arg SetTaskBudgetParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, setTaskBudget,
+		arg.ID,
+		arg.BudgetMinutes,
+		arg.BudgetPolicy,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const setTaskDuration = `-- name: SetTaskDuration :one
+UPDATE tasks
+SET
+	duration = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type SetTaskDurationParams struct {
+	ID             uuid.UUID `json:"id"`
+	Duration       string    `json:"duration"`
+	LastModifiedAt int64     `json:"last_modified_at"`
+}
+
+func (q *Queries) SetTaskDuration(ctx context.Context, arg SetTaskDurationParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, setTaskDuration,
+		arg.ID,
+		arg.Duration,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const setTaskEstimateAlerts = `-- name: SetTaskEstimateAlerts :exec
+UPDATE tasks
+SET
+	estimate_warned_at = $2,
+	estimate_exceeded_at = $3
+WHERE id = $1
+`
+
+type SetTaskEstimateAlertsParams struct {
+	ID                 uuid.UUID    `json:"id"`
+	EstimateWarnedAt   sql.NullTime `json:"estimate_warned_at"`
+	EstimateExceededAt sql.NullTime `json:"estimate_exceeded_at"`
+}
+
+func (q *Queries) SetTaskEstimateAlerts(ctx context.Context, arg SetTaskEstimateAlertsParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskEstimateAlerts,
+		arg.ID,
+		arg.EstimateWarnedAt,
+		arg.EstimateExceededAt,
+	)
+	return err
+}
+
+const setTaskHiddenUntil = `-- name: SetTaskHiddenUntil :one
+UPDATE tasks
+SET
+	hidden_until = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type SetTaskHiddenUntilParams struct {
+	ID             uuid.UUID    `json:"id"`
+	HiddenUntil    sql.NullTime `json:"hidden_until"`
+	LastModifiedAt int64        `json:"last_modified_at"`
+}
+
+func (q *Queries) SetTaskHiddenUntil(ctx context.Context, arg SetTaskHiddenUntilParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, setTaskHiddenUntil,
+		arg.ID,
+		arg.HiddenUntil,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const setTaskOverdueNotifiedCount = `-- name: SetTaskOverdueNotifiedCount :exec
+UPDATE tasks
+SET overdue_notified_count = $2
+WHERE id = $1
+`
+
+type SetTaskOverdueNotifiedCountParams struct {
+	ID                   uuid.UUID `json:"id"`
+	OverdueNotifiedCount int32     `json:"overdue_notified_count"`
+}
+
+func (q *Queries) SetTaskOverdueNotifiedCount(ctx context.Context, arg SetTaskOverdueNotifiedCountParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskOverdueNotifiedCount, arg.ID, arg.OverdueNotifiedCount)
+	return err
+}
+
+const setTaskOverdueState = `-- name: SetTaskOverdueState :exec
+UPDATE tasks
+SET
+	overdue_since = $2,
+	overdue_notified_count = $3
+WHERE id = $1
+`
+
+type SetTaskOverdueStateParams struct {
+	ID                   uuid.UUID    `json:"id"`
+	OverdueSince         sql.NullTime `json:"overdue_since"`
+	OverdueNotifiedCount int32        `json:"overdue_notified_count"`
+}
+
+func (q *Queries) SetTaskOverdueState(ctx context.Context, arg SetTaskOverdueStateParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskOverdueState,
+		arg.ID,
+		arg.OverdueSince,
+		arg.OverdueNotifiedCount,
+	)
+	return err
+}
+
+const setTaskProject = `-- name: SetTaskProject :one
+UPDATE tasks
+SET
+	project_id = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type SetTaskProjectParams struct {
+	ID             uuid.UUID     `json:"id"`
+	ProjectID      uuid.NullUUID `json:"project_id"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) SetTaskProject(ctx context.Context, arg SetTaskProjectParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, setTaskProject,
+		arg.ID,
+		arg.ProjectID,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const setTaskSortPosition = `-- name: SetTaskSortPosition :one
+UPDATE tasks
+SET
+	sort_position = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type SetTaskSortPositionParams struct {
+	ID             uuid.UUID `json:"id"`
+	SortPosition   float64   `json:"sort_position"`
+	LastModifiedAt int64     `json:"last_modified_at"`
+}
+
+func (q *Queries) SetTaskSortPosition(ctx context.Context, arg SetTaskSortPositionParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, setTaskSortPosition,
+		arg.ID,
+		arg.SortPosition,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
+const setTaskState = `-- name: SetTaskState :one
+UPDATE tasks
+SET
+	state_id = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
+`
+
+type SetTaskStateParams struct {
+	ID             uuid.UUID     `json:"id"`
+	StateID        uuid.NullUUID `json:"state_id"`
+	LastModifiedAt int64         `json:"last_modified_at"`
+}
+
+func (q *Queries) SetTaskState(ctx context.Context, arg SetTaskStateParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, setTaskState,
+		arg.ID,
+		arg.StateID,
+		arg.LastModifiedAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.Duration,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.ToggledAt,
+		&i.IsActive,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.LastModifiedAt,
+		&i.Priority,
+		&i.DueAt,
+		&i.ShowBeforeDueTime,
+		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
+	)
+	return i, err
+}
+
 const toggleTask = `-- name: ToggleTask :one
 UPDATE tasks
 SET 
@@ -706,7 +2537,7 @@ SET
 	last_modified_at = $5
 WHERE 
 	id = $1
-RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from
+RETURNING id, title, description, created_at, completed_at, duration, category, tags, toggled_at, is_active, is_completed, user_id, last_modified_at, priority, due_at, show_before_due_time, visible_from, blocked, sort_position, estimate_minutes, estimate_warned_at, estimate_exceeded_at, continuation_of, focus_sessions_completed, checklist_total, checklist_checked, hidden_until, overdue_since, overdue_notified_count, state_id, project_id, budget_minutes, budget_policy, budget_warned_at, budget_exhausted_at
 `
 
 type ToggleTaskParams struct {
@@ -744,6 +2575,24 @@ func (q *Queries) ToggleTask(ctx context.Context, arg ToggleTaskParams) (Task, e
 		&i.DueAt,
 		&i.ShowBeforeDueTime,
 		&i.VisibleFrom,
+		&i.Blocked,
+		&i.SortPosition,
+		&i.EstimateMinutes,
+		&i.EstimateWarnedAt,
+		&i.EstimateExceededAt,
+		&i.ContinuationOf,
+		&i.FocusSessionsCompleted,
+		&i.ChecklistTotal,
+		&i.ChecklistChecked,
+		&i.HiddenUntil,
+		&i.OverdueSince,
+		&i.OverdueNotifiedCount,
+		&i.StateID,
+		&i.ProjectID,
+		&i.BudgetMinutes,
+		&i.BudgetPolicy,
+		&i.BudgetWarnedAt,
+		&i.BudgetExhaustedAt,
 	)
 	return i, err
 }
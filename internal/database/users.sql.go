@@ -8,8 +8,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createUser = `-- name: CreateUser :one
@@ -26,7 +28,7 @@ INSERT INTO users (
 )
 ON CONFLICT (email)
 DO NOTHING
-RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid
+RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on
 `
 
 type CreateUserParams struct {
@@ -54,12 +56,28 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Categories,
 		&i.KeyCommands,
 		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid FROM users WHERE email = $1
+SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -75,12 +93,28 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Categories,
 		&i.KeyCommands,
 		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
 	)
 	return i, err
 }
 
 const getUserByGoogleUID = `-- name: GetUserByGoogleUID :one
-SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid FROM users WHERE google_uid = $1
+SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on FROM users WHERE google_uid = $1
 `
 
 func (q *Queries) GetUserByGoogleUID(ctx context.Context, googleUid sql.NullString) (User, error) {
@@ -96,6 +130,59 @@ func (q *Queries) GetUserByGoogleUID(ctx context.Context, googleUid sql.NullStri
 		&i.Categories,
 		&i.KeyCommands,
 		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Categories,
+		&i.KeyCommands,
+		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
 	)
 	return i, err
 }
@@ -118,13 +205,311 @@ func (q *Queries) GetUserSettings(ctx context.Context, id uuid.UUID) (GetUserSet
 	return i, err
 }
 
+const getUsersDueForRollover = `-- name: GetUsersDueForRollover :many
+SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on FROM users
+WHERE last_rollover_at < (
+	date_trunc('day', (NOW() AT TIME ZONE timezone) - make_interval(hours => rollover_hour) + INTERVAL '1 minute')
+	+ make_interval(hours => rollover_hour)
+	- INTERVAL '1 minute'
+) AT TIME ZONE timezone
+`
+
+func (q *Queries) GetUsersDueForRollover(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersDueForRollover)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Categories,
+			&i.KeyCommands,
+			&i.GoogleUid,
+			&i.Timezone,
+			&i.RolloverHour,
+			&i.LastRolloverAt,
+			&i.RolloverMode,
+			&i.LastActivityAt,
+			&i.IdleThresholdMinutes,
+			&i.WorkStartMinutes,
+			&i.WorkEndMinutes,
+			pq.Array(&i.WorkDays),
+			&i.NudgeAfterMinutes,
+			&i.NudgeIntervalMinutes,
+			&i.NothingRunningSince,
+			&i.LastNudgedAt,
+			pq.Array(&i.OverdueEscalationMinutes),
+			&i.DayGapsNotify,
+			&i.DayGapsNotifiedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersWithDayGapsNotify = `-- name: GetUsersWithDayGapsNotify :many
+SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on FROM users
+WHERE day_gaps_notify = TRUE
+	AND work_start_minutes IS NOT NULL
+	AND work_end_minutes IS NOT NULL
+`
+
+func (q *Queries) GetUsersWithDayGapsNotify(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersWithDayGapsNotify)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Categories,
+			&i.KeyCommands,
+			&i.GoogleUid,
+			&i.Timezone,
+			&i.RolloverHour,
+			&i.LastRolloverAt,
+			&i.RolloverMode,
+			&i.LastActivityAt,
+			&i.IdleThresholdMinutes,
+			&i.WorkStartMinutes,
+			&i.WorkEndMinutes,
+			pq.Array(&i.WorkDays),
+			&i.NudgeAfterMinutes,
+			&i.NudgeIntervalMinutes,
+			&i.NothingRunningSince,
+			&i.LastNudgedAt,
+			pq.Array(&i.OverdueEscalationMinutes),
+			&i.DayGapsNotify,
+			&i.DayGapsNotifiedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersWithPendingOverdueEscalations = `-- name: GetUsersWithPendingOverdueEscalations :many
+SELECT u.id, u.first_name, u.last_name, u.email, u.created_at, u.updated_at, u.categories, u.key_commands, u.google_uid, u.timezone, u.rollover_hour, u.last_rollover_at, u.rollover_mode, u.last_activity_at, u.idle_threshold_minutes, u.work_start_minutes, u.work_end_minutes, u.work_days, u.nudge_after_minutes, u.nudge_interval_minutes, u.nothing_running_since, u.last_nudged_at, u.overdue_escalation_minutes, u.day_gaps_notify, u.day_gaps_notified_on FROM users u
+WHERE EXISTS (
+	SELECT 1 FROM tasks t
+	WHERE t.user_id = u.id
+		AND t.is_completed = FALSE
+		AND t.overdue_since IS NOT NULL
+		AND t.overdue_notified_count < cardinality(u.overdue_escalation_minutes)
+)
+`
+
+func (q *Queries) GetUsersWithPendingOverdueEscalations(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersWithPendingOverdueEscalations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Categories,
+			&i.KeyCommands,
+			&i.GoogleUid,
+			&i.Timezone,
+			&i.RolloverHour,
+			&i.LastRolloverAt,
+			&i.RolloverMode,
+			&i.LastActivityAt,
+			&i.IdleThresholdMinutes,
+			&i.WorkStartMinutes,
+			&i.WorkEndMinutes,
+			pq.Array(&i.WorkDays),
+			&i.NudgeAfterMinutes,
+			&i.NudgeIntervalMinutes,
+			&i.NothingRunningSince,
+			&i.LastNudgedAt,
+			pq.Array(&i.OverdueEscalationMinutes),
+			&i.DayGapsNotify,
+			&i.DayGapsNotifiedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersWithWorkingHours = `-- name: GetUsersWithWorkingHours :many
+SELECT id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on FROM users
+WHERE work_start_minutes IS NOT NULL AND work_end_minutes IS NOT NULL
+`
+
+func (q *Queries) GetUsersWithWorkingHours(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersWithWorkingHours)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Categories,
+			&i.KeyCommands,
+			&i.GoogleUid,
+			&i.Timezone,
+			&i.RolloverHour,
+			&i.LastRolloverAt,
+			&i.RolloverMode,
+			&i.LastActivityAt,
+			&i.IdleThresholdMinutes,
+			&i.WorkStartMinutes,
+			&i.WorkEndMinutes,
+			pq.Array(&i.WorkDays),
+			&i.NudgeAfterMinutes,
+			&i.NudgeIntervalMinutes,
+			&i.NothingRunningSince,
+			&i.LastNudgedAt,
+			pq.Array(&i.OverdueEscalationMinutes),
+			&i.DayGapsNotify,
+			&i.DayGapsNotifiedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markUserNudged = `-- name: MarkUserNudged :exec
+UPDATE users
+SET last_nudged_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkUserNudged(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markUserNudged, id)
+	return err
+}
+
+const markUserRolledOver = `-- name: MarkUserRolledOver :exec
+UPDATE users
+SET last_rollover_at = $2
+WHERE id = $1
+`
+
+type MarkUserRolledOverParams struct {
+	ID             uuid.UUID `json:"id"`
+	LastRolloverAt time.Time `json:"last_rollover_at"`
+}
+
+func (q *Queries) MarkUserRolledOver(ctx context.Context, arg MarkUserRolledOverParams) error {
+	_, err := q.db.ExecContext(ctx, markUserRolledOver, arg.ID, arg.LastRolloverAt)
+	return err
+}
+
+const setUserDayGapsNotifiedOn = `-- name: SetUserDayGapsNotifiedOn :exec
+UPDATE users
+SET day_gaps_notified_on = $2
+WHERE id = $1
+`
+
+type SetUserDayGapsNotifiedOnParams struct {
+	ID                uuid.UUID    `json:"id"`
+	DayGapsNotifiedOn sql.NullTime `json:"day_gaps_notified_on"`
+}
+
+func (q *Queries) SetUserDayGapsNotifiedOn(ctx context.Context, arg SetUserDayGapsNotifiedOnParams) error {
+	_, err := q.db.ExecContext(ctx, setUserDayGapsNotifiedOn, arg.ID, arg.DayGapsNotifiedOn)
+	return err
+}
+
+const setUserNothingRunningSince = `-- name: SetUserNothingRunningSince :exec
+UPDATE users
+SET nothing_running_since = $2
+WHERE id = $1
+`
+
+type SetUserNothingRunningSinceParams struct {
+	ID                  uuid.UUID    `json:"id"`
+	NothingRunningSince sql.NullTime `json:"nothing_running_since"`
+}
+
+func (q *Queries) SetUserNothingRunningSince(ctx context.Context, arg SetUserNothingRunningSinceParams) error {
+	_, err := q.db.ExecContext(ctx, setUserNothingRunningSince, arg.ID, arg.NothingRunningSince)
+	return err
+}
+
+const touchUserActivity = `-- name: TouchUserActivity :exec
+UPDATE users
+SET last_activity_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) TouchUserActivity(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, touchUserActivity, id)
+	return err
+}
+
 const updateUserCategories = `-- name: UpdateUserCategories :one
 UPDATE users
 SET
 	categories = $2
 WHERE
 	id = $1
-RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid
+RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on
 `
 
 type UpdateUserCategoriesParams struct {
@@ -145,6 +530,22 @@ func (q *Queries) UpdateUserCategories(ctx context.Context, arg UpdateUserCatego
 		&i.Categories,
 		&i.KeyCommands,
 		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
 	)
 	return i, err
 }
@@ -155,7 +556,7 @@ SET
 	key_commands = $2
 WHERE
 	id = $1
-RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid
+RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on
 `
 
 type UpdateUserCommandsParams struct {
@@ -176,6 +577,165 @@ func (q *Queries) UpdateUserCommands(ctx context.Context, arg UpdateUserCommands
 		&i.Categories,
 		&i.KeyCommands,
 		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
+	)
+	return i, err
+}
+
+const updateUserDayGapsNotify = `-- name: UpdateUserDayGapsNotify :exec
+UPDATE users
+SET day_gaps_notify = $2,
+	updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserDayGapsNotifyParams struct {
+	ID            uuid.UUID `json:"id"`
+	DayGapsNotify bool      `json:"day_gaps_notify"`
+}
+
+func (q *Queries) UpdateUserDayGapsNotify(ctx context.Context, arg UpdateUserDayGapsNotifyParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserDayGapsNotify, arg.ID, arg.DayGapsNotify)
+	return err
+}
+
+const updateUserIdleThreshold = `-- name: UpdateUserIdleThreshold :exec
+UPDATE users
+SET
+	idle_threshold_minutes = $2,
+	updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserIdleThresholdParams struct {
+	ID                   uuid.UUID `json:"id"`
+	IdleThresholdMinutes int32     `json:"idle_threshold_minutes"`
+}
+
+func (q *Queries) UpdateUserIdleThreshold(ctx context.Context, arg UpdateUserIdleThresholdParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserIdleThreshold, arg.ID, arg.IdleThresholdMinutes)
+	return err
+}
+
+const updateUserOverdueEscalation = `-- name: UpdateUserOverdueEscalation :exec
+UPDATE users
+SET overdue_escalation_minutes = $2,
+	updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserOverdueEscalationParams struct {
+	ID                       uuid.UUID `json:"id"`
+	OverdueEscalationMinutes []int32   `json:"overdue_escalation_minutes"`
+}
+
+func (q *Queries) UpdateUserOverdueEscalation(ctx context.Context, arg UpdateUserOverdueEscalationParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserOverdueEscalation, arg.ID, pq.Array(arg.OverdueEscalationMinutes))
+	return err
+}
+
+const updateUserTimeSettings = `-- name: UpdateUserTimeSettings :one
+UPDATE users
+SET
+	timezone = $2,
+	rollover_hour = $3,
+	rollover_mode = $4,
+	last_rollover_at = NOW(),
+	updated_at = NOW()
+WHERE
+	id = $1
+RETURNING id, first_name, last_name, email, created_at, updated_at, categories, key_commands, google_uid, timezone, rollover_hour, last_rollover_at, rollover_mode, last_activity_at, idle_threshold_minutes, work_start_minutes, work_end_minutes, work_days, nudge_after_minutes, nudge_interval_minutes, nothing_running_since, last_nudged_at, overdue_escalation_minutes, day_gaps_notify, day_gaps_notified_on
+`
+
+type UpdateUserTimeSettingsParams struct {
+	ID           uuid.UUID `json:"id"`
+	Timezone     string    `json:"timezone"`
+	RolloverHour int32     `json:"rollover_hour"`
+	RolloverMode string    `json:"rollover_mode"`
+}
+
+func (q *Queries) UpdateUserTimeSettings(ctx context.Context, arg UpdateUserTimeSettingsParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserTimeSettings,
+		arg.ID,
+		arg.Timezone,
+		arg.RolloverHour,
+		arg.RolloverMode,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Categories,
+		&i.KeyCommands,
+		&i.GoogleUid,
+		&i.Timezone,
+		&i.RolloverHour,
+		&i.LastRolloverAt,
+		&i.RolloverMode,
+		&i.LastActivityAt,
+		&i.IdleThresholdMinutes,
+		&i.WorkStartMinutes,
+		&i.WorkEndMinutes,
+		pq.Array(&i.WorkDays),
+		&i.NudgeAfterMinutes,
+		&i.NudgeIntervalMinutes,
+		&i.NothingRunningSince,
+		&i.LastNudgedAt,
+		pq.Array(&i.OverdueEscalationMinutes),
+		&i.DayGapsNotify,
+		&i.DayGapsNotifiedOn,
 	)
 	return i, err
 }
+
+const updateUserWorkingHours = `-- name: UpdateUserWorkingHours :exec
+UPDATE users
+SET
+	work_start_minutes = $2,
+	work_end_minutes = $3,
+	work_days = $4,
+	nudge_after_minutes = $5,
+	nudge_interval_minutes = $6,
+	updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserWorkingHoursParams struct {
+	ID                   uuid.UUID     `json:"id"`
+	WorkStartMinutes     sql.NullInt32 `json:"work_start_minutes"`
+	WorkEndMinutes       sql.NullInt32 `json:"work_end_minutes"`
+	WorkDays             []int32       `json:"work_days"`
+	NudgeAfterMinutes    int32         `json:"nudge_after_minutes"`
+	NudgeIntervalMinutes int32         `json:"nudge_interval_minutes"`
+}
+
+func (q *Queries) UpdateUserWorkingHours(ctx context.Context, arg UpdateUserWorkingHoursParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserWorkingHours,
+		arg.ID,
+		arg.WorkStartMinutes,
+		arg.WorkEndMinutes,
+		pq.Array(arg.WorkDays),
+		arg.NudgeAfterMinutes,
+		arg.NudgeIntervalMinutes,
+	)
+	return err
+}
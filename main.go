@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/dinopy/taskbar2_server/internal/database"
@@ -19,14 +20,23 @@ import (
 )
 
 type config struct {
-	DB                *database.Queries
-	DBPool            *sql.DB
-	PORT              string
-	WSCfg             WebSocketCfg
-	WSClientManager   ClientManager
-	Metrics           *prometheus.Registry
-	ScheduleService   *ScheduleService
-	DispatcherService *DispatcherService
+	DB                 *database.Queries
+	DBPool             *sql.DB
+	PORT               string
+	WSCfg              WebSocketCfg
+	WSClientManager    ClientManager
+	Metrics            *prometheus.Registry
+	ScheduleService    *ScheduleService
+	DispatcherService  *DispatcherService
+	OverrunService     *OverrunService
+	FocusService       *FocusService
+	IdleService        *IdleService
+	NudgeService       *NudgeService
+	OverdueService     *OverdueService
+	BudgetService      *BudgetService
+	DayGapsService     *DayGapsService
+	GoalService        *GoalService
+	AchievementService *AchievementService
 }
 
 type WebSocketCfg struct {
@@ -55,6 +65,15 @@ func main() {
 		log.Fatal("Could not load PORT env")
 	}
 
+	// Percentage of a task's estimate at which a heads-up is sent before the overrun itself
+	estimateWarningPercent := 80
+	if value := os.Getenv("ESTIMATE_WARNING_PERCENT"); value != "" {
+		estimateWarningPercent, err = strconv.Atoi(value)
+		if err != nil {
+			log.Fatalf("Invalid ESTIMATE_WARNING_PERCENT. Err: %v", err)
+		}
+	}
+
 	db, err := sql.Open("postgres", DB_URL)
 	if err != nil {
 		log.Fatalf("Could not connect to DB. Err: %v", err)
@@ -91,11 +110,45 @@ func main() {
 		cfg.WSClientManager.BroadcastToSameUser(context.Background(), "notification_created", userID, notification)
 		return nil
 	})
+	overrunService := NewOverrunService(dbQuery, estimateWarningPercent, dispatcherService.Notify, func(task database.Task) {
+		cfg.WSClientManager.BroadcastToSameUser(context.Background(), "related_task_edited", task.UserID, task)
+		cfg.emitNotificationUnseenCount(context.Background(), task.UserID)
+	})
+	// Services that raise notifications on their own also refresh the unseen badge
+	notify := func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error) {
+		notification, err := dispatcherService.Notify(ctx, params)
+		if err == nil {
+			cfg.emitNotificationUnseenCount(ctx, params.UserID)
+		}
+		return notification, err
+	}
+	broadcast := func(userID uuid.UUID, event string, data interface{}) {
+		cfg.WSClientManager.BroadcastToSameUser(context.Background(), event, userID, data)
+	}
+	focusService := NewFocusService(dbQuery, notify, broadcast)
+	idleService := NewIdleService(dbQuery, notify, func(task database.Task) {
+		cfg.WSClientManager.BroadcastToSameUser(context.Background(), "related_task_toggled", task.UserID, task)
+	})
+	nudgeService := NewNudgeService(dbQuery, notify, broadcast)
+	overdueService := NewOverdueService(dbQuery, notify, broadcast)
+	budgetService := NewBudgetService(dbQuery, notify, broadcast)
+	dayGapsService := NewDayGapsService(dbQuery, notify)
+	achievementService := NewAchievementService(dbQuery, notify, broadcast)
+	goalService := NewGoalService(dbQuery, achievementService, broadcast)
 	cleanupService := NewCleanupService(dbQuery)
 
 	// Update config with services
 	cfg.ScheduleService = scheduleService
 	cfg.DispatcherService = dispatcherService
+	cfg.OverrunService = overrunService
+	cfg.FocusService = focusService
+	cfg.IdleService = idleService
+	cfg.NudgeService = nudgeService
+	cfg.OverdueService = overdueService
+	cfg.BudgetService = budgetService
+	cfg.DayGapsService = dayGapsService
+	cfg.GoalService = goalService
+	cfg.AchievementService = achievementService
 
 	// set up router
 	mux := http.NewServeMux()
@@ -105,6 +158,7 @@ func main() {
 
 	// APIs, I'd like to add some in the future.
 	mux.HandleFunc("/api/hello", cfg.HelloApiHandler)
+	mux.HandleFunc("/api/reports", cfg.ReportsApiHandler)
 
 	// Prometheus metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
@@ -121,7 +175,8 @@ func main() {
 
 	location, _ := time.LoadLocation("Europe/Bucharest")
 	cron := cron.New(cron.WithLocation(location))
-	cron.AddFunc("59 23 * * *", cfg.WSOnMidnightTaskRefresh)
+	// Rollover is per user (timezone + rollover hour), so check every minute who is due
+	cron.AddFunc("@every 1m", cfg.WSOnMidnightTaskRefresh)
 	cron.AddFunc("@every 1m", cfg.DispatchDueNotifications)
 
 	// Add planner and dispatcher loops
@@ -139,6 +194,72 @@ func main() {
 		}
 	})
 
+	cron.AddFunc("@every 1m", func() {
+		ctx := context.Background()
+		if err := cfg.OverrunService.Tick(ctx); err != nil {
+			log.Printf("OverrunService tick failed: %v", err)
+		}
+	})
+
+	// Focus phases are minutes long, so check more often than the other loops
+	cron.AddFunc("@every 15s", func() {
+		ctx := context.Background()
+		if err := cfg.FocusService.Tick(ctx); err != nil {
+			log.Printf("FocusService tick failed: %v", err)
+		}
+	})
+
+	cron.AddFunc("@every 1m", func() {
+		ctx := context.Background()
+		if err := cfg.IdleService.Tick(ctx); err != nil {
+			log.Printf("IdleService tick failed: %v", err)
+		}
+	})
+
+	cron.AddFunc("@every 1m", func() {
+		ctx := context.Background()
+		if err := cfg.NudgeService.Tick(ctx); err != nil {
+			log.Printf("NudgeService tick failed: %v", err)
+		}
+	})
+
+	cron.AddFunc("@every 1m", func() {
+		ctx := context.Background()
+		if err := cfg.OverdueService.Tick(ctx); err != nil {
+			log.Printf("OverdueService tick failed: %v", err)
+		}
+	})
+
+	cron.AddFunc("@every 1m", func() {
+		ctx := context.Background()
+		if err := cfg.BudgetService.Tick(ctx); err != nil {
+			log.Printf("BudgetService tick failed: %v", err)
+		}
+	})
+
+	cron.AddFunc("@every 1m", func() {
+		ctx := context.Background()
+		if err := cfg.DayGapsService.Tick(ctx); err != nil {
+			log.Printf("DayGapsService tick failed: %v", err)
+		}
+	})
+
+	// Keeps goal progress live while tasks run and closes out limits when a period ends
+	cron.AddFunc("@every 5m", func() {
+		ctx := context.Background()
+		if err := cfg.GoalService.Tick(ctx); err != nil {
+			log.Printf("GoalService tick failed: %v", err)
+		}
+	})
+
+	// Achievement rules look at finished days and weeks, so a slow loop is enough
+	cron.AddFunc("@every 30m", func() {
+		ctx := context.Background()
+		if err := cfg.AchievementService.Tick(ctx); err != nil {
+			log.Printf("AchievementService tick failed: %v", err)
+		}
+	})
+
 	// Add daily cleanup job (runs at 3 AM)
 	cron.AddFunc("0 3 * * *", func() {
 		ctx := context.Background()
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+// NudgeService reminds users during their working hours when no task is running
+type NudgeService struct {
+	queries   *database.Queries
+	notify    func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	broadcast func(userID uuid.UUID, event string, data interface{})
+}
+
+func NewNudgeService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), broadcast func(userID uuid.UUID, event string, data interface{})) *NudgeService {
+	return &NudgeService{
+		queries:   queries,
+		notify:    notify,
+		broadcast: broadcast,
+	}
+}
+
+func (s *NudgeService) Tick(ctx context.Context) error {
+	users, err := s.queries.GetUsersWithWorkingHours(ctx)
+	if err != nil {
+		log.Printf("NudgeService: Failed to load users: %v", err)
+		return err
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		if err := s.checkUser(ctx, user, now); err != nil {
+			log.Printf("NudgeService: Failed to check user %s: %v", user.ID, err)
+			// Continue with other users
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *NudgeService) checkUser(ctx context.Context, user database.User, now time.Time) error {
+	// Outside working hours the clock is reset, so the first nudge of a work
+	// period only comes nudge_after_minutes after it starts
+	if !userInWorkingHours(user, now) {
+		return s.clearNothingRunning(ctx, user)
+	}
+
+	active, err := s.queries.HasActiveTask(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if active {
+		return s.clearNothingRunning(ctx, user)
+	}
+
+	if !user.NothingRunningSince.Valid {
+		return s.queries.SetUserNothingRunningSince(ctx, database.SetUserNothingRunningSinceParams{
+			ID:                  user.ID,
+			NothingRunningSince: sql.NullTime{Time: now, Valid: true},
+		})
+	}
+
+	since := user.NothingRunningSince.Time
+	if now.Sub(since) < time.Duration(user.NudgeAfterMinutes)*time.Minute {
+		return nil
+	}
+	// Nudged already for this stretch, wait for the re-nag interval
+	if user.LastNudgedAt.Valid && !user.LastNudgedAt.Time.Before(since) &&
+		now.Sub(user.LastNudgedAt.Time) < time.Duration(user.NudgeIntervalMinutes)*time.Minute {
+		return nil
+	}
+
+	if err := s.queries.MarkUserNudged(ctx, user.ID); err != nil {
+		return err
+	}
+
+	idleMinutes := int64(now.Sub(since).Minutes())
+	log.Printf("NudgeService: Nothing running for user %s for %d minutes", user.ID, idleMinutes)
+
+	if s.broadcast != nil {
+		s.broadcast(user.ID, "no_active_task", struct {
+			Since   time.Time `json:"since"`
+			Minutes int64     `json:"minutes"`
+		}{
+			Since:   since,
+			Minutes: idleMinutes,
+		})
+	}
+
+	if s.notify == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":    "no_active_task",
+		"since":   since,
+		"minutes": idleMinutes,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		Title:            "Nothing Is Running",
+		Description:      sql.NullString{String: fmt.Sprintf("No task has been running for %d minutes.", idleMinutes), Valid: true},
+		Status:           "unseen",
+		NotificationType: "system",
+		Payload:          payload,
+		Priority:         "normal",
+		ExpiresAt:        sql.NullTime{Time: now.Add(time.Duration(user.NudgeIntervalMinutes) * time.Minute), Valid: true},
+		LastModifiedAt:   now.UnixMilli(),
+	})
+	return err
+}
+
+func (s *NudgeService) clearNothingRunning(ctx context.Context, user database.User) error {
+	if !user.NothingRunningSince.Valid {
+		return nil
+	}
+	return s.queries.SetUserNothingRunningSince(ctx, database.SetUserNothingRunningSinceParams{
+		ID:                  user.ID,
+		NothingRunningSince: sql.NullTime{Valid: false},
+	})
+}
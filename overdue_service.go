@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+// OverdueService marks tasks overdue once their due time passes and sends the
+// owner's escalating overdue notifications
+type OverdueService struct {
+	queries   *database.Queries
+	notify    func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	broadcast func(userID uuid.UUID, event string, data interface{})
+}
+
+func NewOverdueService(queries *database.Queries, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), broadcast func(userID uuid.UUID, event string, data interface{})) *OverdueService {
+	return &OverdueService{
+		queries:   queries,
+		notify:    notify,
+		broadcast: broadcast,
+	}
+}
+
+func (s *OverdueService) Tick(ctx context.Context) error {
+	now := time.Now()
+
+	// Due dates moved out or cleared; a moved due date also starts over
+	cleared, err := s.queries.ClearStaleOverdue(ctx, now.UnixMilli())
+	if err != nil {
+		log.Printf("OverdueService: Failed to clear stale overdue tasks: %v", err)
+		return err
+	}
+	for _, task := range cleared {
+		s.emit(task.UserID, "related_task_edited", task)
+	}
+
+	marked, err := s.queries.MarkTasksOverdue(ctx, now.UnixMilli())
+	if err != nil {
+		log.Printf("OverdueService: Failed to mark overdue tasks: %v", err)
+		return err
+	}
+	buckets := make(map[uuid.UUID][]database.Task)
+	for _, task := range marked {
+		buckets[task.UserID] = append(buckets[task.UserID], task)
+	}
+	for userID, bucket := range buckets {
+		s.emit(userID, "tasks_became_overdue", struct {
+			Tasks []database.Task `json:"tasks"`
+		}{
+			Tasks: bucket,
+		})
+	}
+
+	users, err := s.queries.GetUsersWithPendingOverdueEscalations(ctx)
+	if err != nil {
+		log.Printf("OverdueService: Failed to load users: %v", err)
+		return err
+	}
+	for _, user := range users {
+		if err := s.escalateUser(ctx, user, now); err != nil {
+			log.Printf("OverdueService: Failed to escalate for user %s: %v", user.ID, err)
+			// Continue with other users
+			continue
+		}
+	}
+
+	return nil
+}
+
+// escalateUser sends the latest escalation step each overdue task reached. Steps
+// missed while the server was down are skipped rather than sent in a burst.
+func (s *OverdueService) escalateUser(ctx context.Context, user database.User, now time.Time) error {
+	tasks, err := s.queries.GetOverdueTasksByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	steps := user.OverdueEscalationMinutes
+	for _, task := range tasks {
+		// Deferred tasks catch up once they are visible again
+		if task.HiddenUntil.Valid && task.HiddenUntil.Time.After(now) {
+			continue
+		}
+
+		reached := int32(0)
+		for _, minutes := range steps {
+			if task.OverdueSince.Time.Add(time.Duration(minutes) * time.Minute).After(now) {
+				break
+			}
+			reached++
+		}
+		if reached <= task.OverdueNotifiedCount {
+			continue
+		}
+
+		err := s.queries.SetTaskOverdueNotifiedCount(ctx, database.SetTaskOverdueNotifiedCountParams{
+			ID:                   task.ID,
+			OverdueNotifiedCount: reached,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.sendNotification(ctx, task, int(reached), len(steps), now); err != nil {
+			log.Printf("OverdueService: Failed to notify for task %s: %v", task.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *OverdueService) sendNotification(ctx context.Context, task database.Task, step, steps int, now time.Time) error {
+	if s.notify == nil {
+		return nil
+	}
+
+	overdueMinutes := int64(now.Sub(task.OverdueSince.Time).Minutes())
+
+	title := "Task Overdue"
+	priority := "normal"
+	if step > 1 {
+		title = "Task Still Overdue"
+		priority = "high"
+	}
+	if step > 1 && step == steps {
+		priority = "urgent"
+	}
+
+	description := fmt.Sprintf("'%s' is past its due time.", task.Title)
+	if overdueMinutes > 0 {
+		description = fmt.Sprintf("'%s' was due %s ago.", task.Title, formatSuggestionDuration(now.Sub(task.OverdueSince.Time)))
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":            "overdue",
+		"task_id":         task.ID,
+		"title":           task.Title,
+		"due_at":          task.DueAt.Time,
+		"overdue_since":   task.OverdueSince.Time,
+		"overdue_minutes": overdueMinutes,
+		"step":            step,
+		"steps":           steps,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           task.UserID,
+		Title:            title,
+		Description:      sql.NullString{String: description, Valid: true},
+		Status:           "unseen",
+		NotificationType: "overdue",
+		Payload:          payload,
+		Priority:         priority,
+		ExpiresAt:        sql.NullTime{Time: now.Add(24 * time.Hour), Valid: true},
+		LastModifiedAt:   now.UnixMilli(),
+	})
+	return err
+}
+
+func (s *OverdueService) emit(userID uuid.UUID, event string, data interface{}) {
+	if s.broadcast == nil {
+		return
+	}
+	s.broadcast(userID, event, data)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/google/uuid"
+)
+
+type OverrunService struct {
+	queries        *database.Queries
+	warningPercent int
+	notify         func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error)
+	onTaskUpdated  func(task database.Task)
+}
+
+func NewOverrunService(queries *database.Queries, warningPercent int, notify func(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error), onTaskUpdated func(task database.Task)) *OverrunService {
+	return &OverrunService{
+		queries:        queries,
+		warningPercent: warningPercent,
+		notify:         notify,
+		onTaskUpdated:  onTaskUpdated,
+	}
+}
+
+func (s *OverrunService) Tick(ctx context.Context) error {
+	tasks, err := s.queries.GetRunningTasksWithEstimate(ctx)
+	if err != nil {
+		log.Printf("OverrunService: Failed to load running tasks: %v", err)
+		return err
+	}
+
+	nowMs := time.Now().UnixMilli()
+	for _, task := range tasks {
+		if err := s.checkTask(ctx, task, nowMs); err != nil {
+			log.Printf("OverrunService: Failed to check task %s: %v", task.ID, err)
+			// Continue checking other tasks
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *OverrunService) checkTask(ctx context.Context, task database.Task, nowMs int64) error {
+	if task.EstimateMinutes.Int32 <= 0 {
+		return nil
+	}
+
+	// Rollovers reset duration every day, the estimate covers the earlier days too
+	earlier, err := s.queries.GetTaskEarlierTrackedSeconds(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	current, err := taskTrackedSeconds(task, nowMs)
+	if err != nil {
+		return err
+	}
+	tracked := earlier + current
+
+	estimate := int64(task.EstimateMinutes.Int32) * 60
+	percent := int(tracked * 100 / estimate)
+
+	var updated database.Task
+	var stage string
+	switch {
+	case percent >= 100:
+		updated, err = s.queries.MarkTaskEstimateExceeded(ctx, database.MarkTaskEstimateExceededParams{
+			ID:             task.ID,
+			LastModifiedAt: nowMs,
+		})
+		stage = "exceeded"
+	case s.warningPercent > 0 && s.warningPercent < 100 && percent >= s.warningPercent && !task.EstimateWarnedAt.Valid:
+		updated, err = s.queries.MarkTaskEstimateWarned(ctx, database.MarkTaskEstimateWarnedParams{
+			ID:             task.ID,
+			LastModifiedAt: nowMs,
+		})
+		stage = "warning"
+	default:
+		return nil
+	}
+	if err == sql.ErrNoRows {
+		// Another tick already flagged it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("OverrunService: Task %s reached %d%% of its %d minute estimate", task.ID, percent, task.EstimateMinutes.Int32)
+
+	// Push the flagged task to clients once the notification is stored
+	defer func() {
+		if s.onTaskUpdated != nil {
+			s.onTaskUpdated(updated)
+		}
+	}()
+
+	if s.notify == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"kind":             "overrun",
+		"stage":            stage,
+		"task_id":          task.ID,
+		"title":            task.Title,
+		"estimate_minutes": task.EstimateMinutes.Int32,
+		"tracked_seconds":  tracked,
+	})
+	if err != nil {
+		return err
+	}
+
+	title := "Estimate Almost Used"
+	description := fmt.Sprintf("'%s' has used %d%% of its %d minute estimate.", task.Title, percent, task.EstimateMinutes.Int32)
+	priority := "normal"
+	if stage == "exceeded" {
+		title = "Estimate Exceeded"
+		description = fmt.Sprintf("'%s' has run past its %d minute estimate.", task.Title, task.EstimateMinutes.Int32)
+		priority = "high"
+	}
+
+	_, err = s.notify(ctx, database.CreateNotificationParams{
+		ID:               uuid.New(),
+		UserID:           task.UserID,
+		Title:            title,
+		Description:      sql.NullString{String: description, Valid: true},
+		Status:           "unseen",
+		NotificationType: "overrun",
+		Payload:          payload,
+		Priority:         priority,
+		ExpiresAt:        sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true},
+		LastModifiedAt:   nowMs,
+	})
+	return err
+}
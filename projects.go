@@ -0,0 +1,438 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+// defaultProjectDeadlineOffsets are the minutes before a project's deadline at which
+// its reminders go out, a week ahead down to the deadline itself
+var defaultProjectDeadlineOffsets = []int32{10080, 2880, 1440, 0}
+
+var projectStatuses = map[string]struct{}{
+	"active":    {},
+	"on_hold":   {},
+	"completed": {},
+	"archived":  {},
+}
+
+type projectT struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Category      *string    `json:"category"`
+	Deadline      *time.Time `json:"deadline"`
+	Status        string     `json:"status"`
+	BudgetMinutes *int32     `json:"budget_minutes"`
+}
+
+// projectRollup is a project with the totals of its tasks
+type projectRollup struct {
+	Project         database.Project `json:"project"`
+	OpenCount       int64            `json:"open_count"`
+	DoneCount       int64            `json:"done_count"`
+	TrackedSeconds  int64            `json:"tracked_seconds"`
+	PercentComplete int64            `json:"percent_complete"`
+}
+
+func validateProject(project projectT) string {
+	if strings.TrimSpace(project.Name) == "" {
+		return "name is required"
+	}
+	if _, ok := projectStatuses[project.Status]; !ok && project.Status != "" {
+		return "status must be 'active', 'on_hold', 'completed' or 'archived'"
+	}
+	return ""
+}
+
+func projectCategory(category *string) sql.NullString {
+	if category == nil || strings.TrimSpace(*category) == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strings.TrimSpace(*category), Valid: true}
+}
+
+func projectDeadline(deadline *time.Time) sql.NullTime {
+	if deadline == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: deadline.UTC(), Valid: true}
+}
+
+// planProjectDeadlineJobs is planTaskDueJobs for projects: pending deadline reminders
+// are replaced, and only active projects with a deadline get new ones
+func planProjectDeadlineJobs(ctx context.Context, queries *database.Queries, project database.Project) {
+	err := queries.CancelPendingJobsForProject(ctx, uuid.NullUUID{UUID: project.ID, Valid: true})
+	if err != nil {
+		logDBError("Failed to cancel deadline reminders for project "+project.ID.String(), err)
+		return
+	}
+	if project.Status != "active" || !project.Deadline.Valid {
+		return
+	}
+
+	now := time.Now()
+	for _, off := range defaultProjectDeadlineOffsets {
+		sendAt := project.Deadline.Time.Add(-time.Duration(off) * time.Minute)
+		if sendAt.Before(now) {
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"project_id":     project.ID.String(),
+			"offset_minutes": off,
+			"title":          project.Name,
+			"kind":           "project",
+			"deadline":       project.Deadline.Time,
+		}
+		payloadJSON, _ := json.Marshal(payload)
+
+		err := queries.UpsertProjectNotificationJob(ctx, database.UpsertProjectNotificationJobParams{
+			UserID:        project.UserID,
+			ProjectID:     uuid.NullUUID{UUID: project.ID, Valid: true},
+			OffsetMinutes: off,
+			PlannedSendAt: sendAt,
+			Payload:       payloadJSON,
+		})
+		if err != nil {
+			logDBError(fmt.Sprintf("Failed to plan %d minute deadline reminder for project %s", off, project.ID), err)
+			return
+		}
+	}
+}
+
+// loadOwnedProject is loadOwnedTask for projects
+func (cfg *config) loadOwnedProject(ctx context.Context, c *websocket.Conn, userID, projectID uuid.UUID) (project database.Project, ok bool, err error) {
+	project, err = cfg.DB.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return project, false, sendError(c, "not_found", "Project not found", 404)
+		}
+		logDBError("Failed to load project "+projectID.String(), err)
+		return project, false, sendError(c, ErrorDatabaseError, "Failed to load project", 500)
+	}
+	if project.UserID != userID {
+		return project, false, sendError(c, "unauthorized", "Project does not belong to user", 403)
+	}
+	return project, true, nil
+}
+
+// projectRollups returns the user's projects with their totals, in creation order
+func (cfg *config) projectRollups(ctx context.Context, userID uuid.UUID, status string) ([]projectRollup, error) {
+	projects, err := cfg.DB.GetProjectsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cfg.DB.GetProjectRollups(ctx, database.GetProjectRollupsParams{
+		NowMs:  time.Now().UnixMilli(),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[uuid.UUID]database.GetProjectRollupsRow, len(rows))
+	for _, row := range rows {
+		totals[row.ProjectID] = row
+	}
+
+	rollups := make([]projectRollup, 0, len(projects))
+	for _, project := range projects {
+		if status != "" && project.Status != status {
+			continue
+		}
+
+		row := totals[project.ID]
+		rollup := projectRollup{
+			Project:        project,
+			OpenCount:      row.OpenCount,
+			DoneCount:      row.DoneCount,
+			TrackedSeconds: row.TrackedSeconds,
+		}
+		if total := row.OpenCount + row.DoneCount; total > 0 {
+			rollup.PercentComplete = row.DoneCount * 100 / total
+		}
+		rollups = append(rollups, rollup)
+	}
+	return rollups, nil
+}
+
+func (cfg *config) WSOnProjectCreate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("project_create").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data projectT `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if message := validateProject(payload.Data); message != "" {
+		return sendError(c, "invalid_request", message, 400)
+	}
+
+	status := payload.Data.Status
+	if status == "" {
+		status = "active"
+	}
+
+	id := payload.Data.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	project, err := cfg.DB.CreateProject(ctx, database.CreateProjectParams{
+		ID:            id,
+		UserID:        client.User.ID,
+		Name:          strings.TrimSpace(payload.Data.Name),
+		Description:   payload.Data.Description,
+		Category:      projectCategory(payload.Data.Category),
+		Deadline:      projectDeadline(payload.Data.Deadline),
+		Status:        status,
+		BudgetMinutes: nullableBudget(payload.Data.BudgetMinutes),
+	})
+	if err != nil {
+		logDBError("Failed to create project for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to create project", 500)
+	}
+
+	planProjectDeadlineJobs(ctx, cfg.DB, project)
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "project_created", client.User.ID, project)
+	return nil
+}
+
+func (cfg *config) WSOnProjectEdit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("project_edit").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data projectT `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	current, ok, err := cfg.loadOwnedProject(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+
+	if message := validateProject(payload.Data); message != "" {
+		return sendError(c, "invalid_request", message, 400)
+	}
+
+	status := payload.Data.Status
+	if status == "" {
+		status = current.Status
+	}
+
+	project, err := cfg.DB.UpdateProject(ctx, database.UpdateProjectParams{
+		ID:            current.ID,
+		Name:          strings.TrimSpace(payload.Data.Name),
+		Description:   payload.Data.Description,
+		Category:      projectCategory(payload.Data.Category),
+		Deadline:      projectDeadline(payload.Data.Deadline),
+		Status:        status,
+		BudgetMinutes: nullableBudget(payload.Data.BudgetMinutes),
+	})
+	if err != nil {
+		logDBError("Failed to update project "+current.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to update project", 500)
+	}
+
+	if project.Status != current.Status || project.Name != current.Name ||
+		project.Deadline.Valid != current.Deadline.Valid || !project.Deadline.Time.Equal(current.Deadline.Time) {
+		planProjectDeadlineJobs(ctx, cfg.DB, project)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "project_updated", client.User.ID, project)
+	return nil
+}
+
+func (cfg *config) WSOnProjectDelete(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("project_delete").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	project, ok, err := cfg.loadOwnedProject(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+
+	tx, err := cfg.DBPool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := cfg.DB.WithTx(tx)
+
+	// The tasks stay, they only leave the project
+	releasedTasks, err := queries.ClearTasksProject(ctx, database.ClearTasksProjectParams{
+		ProjectID:      uuid.NullUUID{UUID: project.ID, Valid: true},
+		LastModifiedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		logDBError("Failed to release tasks of project "+project.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to delete project", 500)
+	}
+
+	if err := queries.DeleteProject(ctx, project.ID); err != nil {
+		logDBError("Failed to delete project "+project.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to delete project", 500)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "project_deleted", client.User.ID, struct {
+		ID uuid.UUID `json:"id"`
+	}{
+		ID: project.ID,
+	})
+
+	for _, task := range releasedTasks {
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	}
+
+	return nil
+}
+
+func (cfg *config) WSOnProjectsList(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("projects_list").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.Status != "" {
+		if _, ok := projectStatuses[payload.Data.Status]; !ok {
+			return sendError(c, "invalid_request", "Unknown project status", 400)
+		}
+	}
+
+	rollups, err := cfg.projectRollups(ctx, client.User.ID, payload.Data.Status)
+	if err != nil {
+		logDBError("Failed to load projects for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load projects", 500)
+	}
+
+	cfg.WSClientManager.SendToClient(ctx, "projects_list", SID, struct {
+		Projects []projectRollup `json:"projects"`
+	}{
+		Projects: rollups,
+	})
+	return nil
+}
+
+// WSOnTaskSetProject assigns a task to a project, or takes it out of its project
+func (cfg *config) WSOnTaskSetProject(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_set_project").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID    uuid.UUID `json:"task_id"`
+			ProjectID uuid.UUID `json:"project_id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	projectID := uuid.NullUUID{}
+	if payload.Data.ProjectID != uuid.Nil {
+		project, ok, err := cfg.loadOwnedProject(ctx, c, client.User.ID, payload.Data.ProjectID)
+		if !ok {
+			return err
+		}
+		projectID = uuid.NullUUID{UUID: project.ID, Valid: true}
+	}
+
+	previousTask := task
+	task, err = cfg.DB.SetTaskProject(ctx, database.SetTaskProjectParams{
+		ID:             task.ID,
+		ProjectID:      projectID,
+		LastModifiedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		logDBError("Failed to set project of task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to update task", 500)
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "edit", &previousTask, task)
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	return nil
+}
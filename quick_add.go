@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+// quickAddToken is one piece of the input the parser understood
+type quickAddToken struct {
+	Text string `json:"text"`
+	Kind string `json:"kind"`
+}
+
+// quickAddResult is the interpretation sent back so the client can show what was understood
+type quickAddResult struct {
+	Title           string          `json:"title"`
+	Category        string          `json:"category"`
+	Tags            []string        `json:"tags"`
+	Priority        *int32          `json:"priority"`
+	DueAt           *time.Time      `json:"due_at"`
+	EstimateMinutes *int32          `json:"estimate_minutes"`
+	Tokens          []quickAddToken `json:"tokens"`
+}
+
+var (
+	quickAddEstimatePattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m?)?$`)
+	quickAddClockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	quickAddWeekdays        = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+)
+
+// parseQuickAdd reads free text like "Call bank tomorrow 9am #Admin +finance !3 ~30m".
+//
+//   - #word sets the category, matched case-insensitively against the user's categories;
+//     unknown ones are only reported as unknown_category tokens
+//   - +word adds a tag
+//   - !N sets the priority
+//   - ~30m, ~1h, ~1h30m or ~45 set the estimate in minutes
+//   - today, tonight, tomorrow, a weekday, "in N days|weeks" or YYYY-MM-DD set the due day
+//   - 9am, 9:30pm, 17:00, noon or midnight set the due time, optionally after "at"
+//
+// Dates and times are read in the user's timezone. A time without a day is today,
+// or tomorrow once it has passed; a day without a time is due at 23:59. A leading
+// backslash keeps a word in the title as written, e.g. \#1.
+func parseQuickAdd(text string, user database.User, categories []string, now time.Time) (quickAddResult, error) {
+	loc := userLocation(user)
+	localNow := now.In(loc)
+
+	result := quickAddResult{Tags: []string{}, Tokens: []quickAddToken{}}
+	var title []string
+	var day *time.Time
+	clock := -1
+
+	words := strings.Fields(text)
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		lower := strings.ToLower(word)
+
+		recognize := func(kind string) {
+			result.Tokens = append(result.Tokens, quickAddToken{Text: word, Kind: kind})
+		}
+
+		switch {
+		case strings.HasPrefix(word, `\`) && len(word) > 1:
+			title = append(title, word[1:])
+
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			// Only existing categories are used; anything else is reported, not created
+			matched := false
+			for _, category := range categories {
+				if strings.EqualFold(category, word[1:]) {
+					result.Category = category
+					matched = true
+					break
+				}
+			}
+			if matched {
+				recognize("category")
+			} else {
+				recognize("unknown_category")
+			}
+
+		case strings.HasPrefix(word, "+") && len(word) > 1:
+			tag := word[1:]
+			duplicate := false
+			for _, existing := range result.Tags {
+				if strings.EqualFold(existing, tag) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				result.Tags = append(result.Tags, tag)
+			}
+			recognize("tag")
+
+		case strings.HasPrefix(word, "!") && len(word) > 1:
+			priority, err := strconv.Atoi(word[1:])
+			if err != nil || priority < 1 {
+				return result, fmt.Errorf("invalid priority %q", word)
+			}
+			value := int32(priority)
+			result.Priority = &value
+			recognize("priority")
+
+		case strings.HasPrefix(word, "~") && len(word) > 1:
+			minutes, ok := parseQuickAddEstimate(lower[1:])
+			if !ok {
+				return result, fmt.Errorf("invalid estimate %q", word)
+			}
+			result.EstimateMinutes = &minutes
+			recognize("estimate")
+
+		case lower == "today" || lower == "tonight":
+			d := localNow
+			day = &d
+			if lower == "tonight" && clock < 0 {
+				clock = 20 * 60
+			}
+			recognize("date")
+
+		case lower == "tomorrow" || lower == "tmr":
+			d := localNow.AddDate(0, 0, 1)
+			day = &d
+			recognize("date")
+
+		case quickAddIsWeekday(lower):
+			ahead := (int(quickAddWeekdays[lower]) - int(localNow.Weekday()) + 7) % 7
+			d := localNow.AddDate(0, 0, ahead)
+			day = &d
+			recognize("date")
+
+		case lower == "in" && i+2 < len(words) && quickAddIsRelativeDay(words[i+1], words[i+2]):
+			amount, _ := strconv.Atoi(words[i+1])
+			unit := strings.ToLower(words[i+2])
+			if strings.HasPrefix(unit, "week") {
+				amount *= 7
+			}
+			d := localNow.AddDate(0, 0, amount)
+			day = &d
+			word = strings.Join(words[i:i+3], " ")
+			recognize("date")
+			i += 2
+
+		case lower == "at" && i+1 < len(words) && quickAddIsClock(strings.ToLower(words[i+1])):
+			i++
+			word = strings.Join(words[i-1:i+1], " ")
+			clock, _ = parseQuickAddClock(strings.ToLower(words[i]))
+			recognize("time")
+
+		case quickAddIsClock(lower) && (strings.Contains(lower, ":") || strings.HasSuffix(lower, "m") || lower == "noon" || lower == "midnight"):
+			// A bare number stays in the title, "Call 3 people" is not a time
+			clock, _ = parseQuickAddClock(lower)
+			recognize("time")
+
+		default:
+			if parsed, err := time.ParseInLocation("2006-01-02", word, loc); err == nil {
+				day = &parsed
+				recognize("date")
+				continue
+			}
+			title = append(title, word)
+		}
+	}
+
+	result.Title = strings.Join(title, " ")
+	if result.Title == "" {
+		return result, fmt.Errorf("title is required")
+	}
+
+	if day != nil || clock >= 0 {
+		var due time.Time
+		switch {
+		case day != nil && clock >= 0:
+			due = time.Date(day.Year(), day.Month(), day.Day(), clock/60, clock%60, 0, 0, loc)
+		case day != nil:
+			due = time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 0, 0, loc)
+		default:
+			due = time.Date(localNow.Year(), localNow.Month(), localNow.Day(), clock/60, clock%60, 0, 0, loc)
+			if !due.After(localNow) {
+				due = due.AddDate(0, 0, 1)
+			}
+		}
+		due = due.UTC()
+		result.DueAt = &due
+	}
+
+	return result, nil
+}
+
+func parseQuickAddEstimate(value string) (int32, bool) {
+	match := quickAddEstimatePattern.FindStringSubmatch(value)
+	if match == nil || (match[1] == "" && match[2] == "") {
+		return 0, false
+	}
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	total := hours*60 + minutes
+	if total <= 0 {
+		return 0, false
+	}
+	return int32(total), true
+}
+
+// parseQuickAddClock returns minutes after midnight for 9am, 9:30pm, 17:00, noon and midnight
+func parseQuickAddClock(value string) (int, bool) {
+	switch value {
+	case "noon":
+		return 12 * 60, true
+	case "midnight":
+		return 0, true
+	}
+
+	match := quickAddClockPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, false
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	if minute > 59 {
+		return 0, false
+	}
+
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, false
+		}
+	}
+	return hour*60 + minute, true
+}
+
+func quickAddIsClock(value string) bool {
+	_, ok := parseQuickAddClock(value)
+	return ok
+}
+
+func quickAddIsWeekday(value string) bool {
+	_, ok := quickAddWeekdays[value]
+	return ok
+}
+
+func quickAddIsRelativeDay(amount, unit string) bool {
+	n, err := strconv.Atoi(amount)
+	if err != nil || n < 0 {
+		return false
+	}
+	switch strings.ToLower(unit) {
+	case "day", "days", "week", "weeks":
+		return true
+	}
+	return false
+}
+
+func (cfg *config) WSOnTaskQuickAdd(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_quick_add").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			Text string `json:"text"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	// Categories are read fresh, the cached user only follows settings updates
+	settings, err := cfg.DB.GetUserSettingsWithTiming(ctx, client.User.ID)
+	if err != nil {
+		logDBError("Failed to load categories for quick add", err)
+		return sendError(c, ErrorDatabaseError, "Failed to load categories", 500)
+	}
+	var categories []string
+	if settings.Categories.Valid && settings.Categories.String != "" {
+		categories = strings.Split(settings.Categories.String, ",")
+	}
+
+	parsed, err := parseQuickAdd(payload.Data.Text, client.User, categories, time.Now())
+	if err != nil {
+		return sendError(c, "invalid_request", err.Error(), 400)
+	}
+
+	category := parsed.Category
+	if category == "" {
+		category = "Life"
+	}
+
+	var priority sql.NullInt32
+	if parsed.Priority != nil {
+		priority = sql.NullInt32{Int32: *parsed.Priority, Valid: true}
+	}
+
+	var dueAt sql.NullTime
+	if parsed.DueAt != nil {
+		dueAt = sql.NullTime{Time: *parsed.DueAt, Valid: true}
+	}
+
+	task, err := cfg.DB.CreateTaskWithTiming(ctx, database.CreateTaskParams{
+		ID:                uuid.New(),
+		Title:             parsed.Title,
+		Description:       "",
+		CreatedAt:         time.Now().UTC(),
+		CompletedAt:       sql.NullTime{Valid: false},
+		Duration:          "00:00:00",
+		Category:          category,
+		Tags:              parsed.Tags,
+		ToggledAt:         sql.NullInt64{Valid: false},
+		IsActive:          false,
+		IsCompleted:       false,
+		UserID:            client.User.ID,
+		LastModifiedAt:    time.Now().UnixMilli(),
+		Priority:          priority,
+		DueAt:             dueAt,
+		ShowBeforeDueTime: sql.NullInt32{Int32: 0, Valid: true},
+		EstimateMinutes:   nullableEstimate(parsed.EstimateMinutes),
+	})
+	if err != nil {
+		logDBError("Failed to create quick add task", err)
+		return sendError(c, ErrorDatabaseError, "Failed to create task", 500)
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "create", nil, task)
+	planTaskDueJobs(ctx, cfg.DB, task)
+
+	parsed.Category = category
+	cfg.WSClientManager.SendToClient(ctx, "task_quick_add", SID, struct {
+		Task   database.Task  `json:"task"`
+		Parsed quickAddResult `json:"parsed"`
+	}{
+		Task:   task,
+		Parsed: parsed,
+	})
+
+	cfg.WSClientManager.BroadcastToSameUserNoIssuer(ctx, "new_task_created", client.User.ID, SID, task)
+	return nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	user := database.User{Timezone: "Europe/Bucharest"}
+	categories := []string{"Work", "Admin"}
+	// Wednesday 13:00 in Bucharest (UTC+3)
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		text     string
+		title    string
+		category string
+		tags     []string
+		priority int32
+		estimate int32
+		due      string
+		kinds    []string
+		wantErr  bool
+	}{
+		{
+			name:     "everything",
+			text:     "Call bank tomorrow 9am #admin +finance !3 ~30m",
+			title:    "Call bank",
+			category: "Admin",
+			tags:     []string{"finance"},
+			priority: 3,
+			estimate: 30,
+			due:      "2024-05-16T06:00:00Z",
+			kinds:    []string{"date", "time", "category", "tag", "priority", "estimate"},
+		},
+		{
+			name:  "at clock later today",
+			text:  "Review at 5pm",
+			title: "Review",
+			due:   "2024-05-15T14:00:00Z",
+			kinds: []string{"time"},
+		},
+		{
+			name:  "past time rolls to tomorrow",
+			text:  "Standup at 9:30",
+			title: "Standup",
+			due:   "2024-05-16T06:30:00Z",
+			kinds: []string{"time"},
+		},
+		{
+			name:  "noon already passed",
+			text:  "Lunch noon",
+			title: "Lunch",
+			due:   "2024-05-16T09:00:00Z",
+			kinds: []string{"time"},
+		},
+		{
+			name:  "bare number stays in the title",
+			text:  "Call 3 people",
+			title: "Call 3 people",
+			kinds: []string{},
+		},
+		{
+			name:  "at without a clock stays in the title",
+			text:  "Meet at office",
+			title: "Meet at office",
+			kinds: []string{},
+		},
+		{
+			name:  "in N weeks",
+			text:  "Ship it in 2 weeks",
+			title: "Ship it",
+			due:   "2024-05-29T20:59:00Z",
+			kinds: []string{"date"},
+		},
+		{
+			name:  "in N days with a time",
+			text:  "Dentist in 2 days at 17:00",
+			title: "Dentist",
+			due:   "2024-05-17T14:00:00Z",
+			kinds: []string{"date", "time"},
+		},
+		{
+			name:  "in without a unit stays in the title",
+			text:  "Buy in 2 apples",
+			title: "Buy in 2 apples",
+			kinds: []string{},
+		},
+		{
+			name:  "weekday equal to today",
+			text:  "Plan wed",
+			title: "Plan",
+			due:   "2024-05-15T20:59:00Z",
+			kinds: []string{"date"},
+		},
+		{
+			name:  "weekday later this week",
+			text:  "Report friday 9am",
+			title: "Report",
+			due:   "2024-05-17T06:00:00Z",
+			kinds: []string{"date", "time"},
+		},
+		{
+			name:  "tonight",
+			text:  "Read tonight",
+			title: "Read",
+			due:   "2024-05-15T17:00:00Z",
+			kinds: []string{"date"},
+		},
+		{
+			name:  "ISO date",
+			text:  "Deadline 2024-06-01",
+			title: "Deadline",
+			due:   "2024-06-01T20:59:00Z",
+			kinds: []string{"date"},
+		},
+		{
+			name:  "escapes",
+			text:  `Fix \#1 and \+1 \tomorrow`,
+			title: "Fix #1 and +1 tomorrow",
+			kinds: []string{},
+		},
+		{
+			name:  "unknown category is reported",
+			text:  "Write #Random",
+			title: "Write",
+			kinds: []string{"unknown_category"},
+		},
+		{
+			name:  "duplicate tags",
+			text:  "Tidy +home +Home +garden",
+			title: "Tidy",
+			tags:  []string{"home", "garden"},
+			kinds: []string{"tag", "tag", "tag"},
+		},
+		{
+			name:     "estimate in hours and minutes",
+			text:     "Write draft ~1h30m",
+			title:    "Write draft",
+			estimate: 90,
+			kinds:    []string{"estimate"},
+		},
+		{name: "empty title", text: "#Work tomorrow", wantErr: true},
+		{name: "bad priority", text: "Task !x", wantErr: true},
+		{name: "bad estimate", text: "Task ~abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQuickAdd(tt.text, user, categories, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Title != tt.title {
+				t.Errorf("title = %q, want %q", got.Title, tt.title)
+			}
+			if got.Category != tt.category {
+				t.Errorf("category = %q, want %q", got.Category, tt.category)
+			}
+			wantTags := tt.tags
+			if wantTags == nil {
+				wantTags = []string{}
+			}
+			if !reflect.DeepEqual(got.Tags, wantTags) {
+				t.Errorf("tags = %v, want %v", got.Tags, wantTags)
+			}
+
+			var priority int32
+			if got.Priority != nil {
+				priority = *got.Priority
+			}
+			if priority != tt.priority {
+				t.Errorf("priority = %d, want %d", priority, tt.priority)
+			}
+
+			var estimate int32
+			if got.EstimateMinutes != nil {
+				estimate = *got.EstimateMinutes
+			}
+			if estimate != tt.estimate {
+				t.Errorf("estimate = %d, want %d", estimate, tt.estimate)
+			}
+
+			due := ""
+			if got.DueAt != nil {
+				due = got.DueAt.Format(time.RFC3339)
+			}
+			if due != tt.due {
+				t.Errorf("due = %q, want %q", due, tt.due)
+			}
+
+			kinds := []string{}
+			for _, token := range got.Tokens {
+				kinds = append(kinds, token.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tt.kinds) {
+				t.Errorf("token kinds = %v, want %v", kinds, tt.kinds)
+			}
+		})
+	}
+}
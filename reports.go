@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+type reportRequest struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Period    string `json:"period"`
+	GroupBy   string `json:"group_by"`
+}
+
+type reportEntry struct {
+	PeriodStart     time.Time `json:"period_start"`
+	Group           string    `json:"group"`
+	TotalSeconds    int64     `json:"total_seconds"`
+	TrackedSeconds  int64     `json:"tracked_seconds"`
+	AdjustedSeconds int64     `json:"adjusted_seconds"`
+	TaskCount       int64     `json:"task_count"`
+	AverageSeconds  int64     `json:"average_seconds"`
+}
+
+type reportResponse struct {
+	StartDate time.Time     `json:"start_date"`
+	EndDate   time.Time     `json:"end_date"`
+	Period    string        `json:"period"`
+	GroupBy   string        `json:"group_by"`
+	Timezone  string        `json:"timezone"`
+	Entries   []reportEntry `json:"entries"`
+}
+
+// reportRequestError marks errors caused by the request rather than the database
+type reportRequestError struct {
+	message string
+}
+
+func (e reportRequestError) Error() string {
+	return e.message
+}
+
+// parseReportDate accepts RFC3339 or a plain YYYY-MM-DD, which is read as the user's day
+func parseReportDate(user database.User, value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", value, userLocation(user))
+	if err != nil {
+		return time.Time{}, reportRequestError{fmt.Sprintf("invalid date %q", value)}
+	}
+
+	dayStart, dayEnd := userDayBounds(user, day.Add(time.Duration(user.RolloverHour)*time.Hour))
+	if end {
+		return dayEnd, nil
+	}
+	return dayStart, nil
+}
+
+// buildReport validates the request and aggregates the user's tracked time in SQL.
+// Day, week and month buckets start at the user's rollover hour in their timezone.
+func (cfg *config) buildReport(ctx context.Context, user database.User, req reportRequest) (reportResponse, error) {
+	if req.Period == "" {
+		req.Period = "day"
+	}
+	if req.GroupBy == "" {
+		req.GroupBy = "category"
+	}
+	if req.Period != "day" && req.Period != "week" && req.Period != "month" {
+		return reportResponse{}, reportRequestError{"period must be 'day', 'week' or 'month'"}
+	}
+	if req.GroupBy != "category" && req.GroupBy != "tag" && req.GroupBy != "priority" {
+		return reportResponse{}, reportRequestError{"group_by must be 'category', 'tag' or 'priority'"}
+	}
+
+	// Defaults to the last 7 days of the user, today included
+	todayStart, todayEnd := userDayBounds(user, time.Now())
+	startDate := todayStart.AddDate(0, 0, -6)
+	endDate := todayEnd
+
+	var err error
+	if req.StartDate != "" {
+		startDate, err = parseReportDate(user, req.StartDate, false)
+		if err != nil {
+			return reportResponse{}, err
+		}
+	}
+	if req.EndDate != "" {
+		endDate, err = parseReportDate(user, req.EndDate, true)
+		if err != nil {
+			return reportResponse{}, err
+		}
+	}
+	if !endDate.After(startDate) {
+		return reportResponse{}, reportRequestError{"end_date must be after start_date"}
+	}
+
+	// endDate is inclusive to the second while the query compares exclusively
+	loc := userLocation(user)
+	rows, err := cfg.DB.GetTimeReportWithTiming(ctx, database.GetTimeReportParams{
+		UserID:       user.ID,
+		Period:       req.Period,
+		Timezone:     loc.String(),
+		RolloverHour: user.RolloverHour,
+		GroupBy:      req.GroupBy,
+		StartAt:      startDate,
+		EndAt:        endDate.Add(time.Second),
+	})
+	if err != nil {
+		return reportResponse{}, err
+	}
+
+	entries := make([]reportEntry, 0, len(rows))
+	for _, row := range rows {
+		// period_start is a wall time in the user's timezone
+		p := row.PeriodStart
+		entries = append(entries, reportEntry{
+			PeriodStart:     time.Date(p.Year(), p.Month(), p.Day(), p.Hour(), p.Minute(), p.Second(), 0, loc),
+			Group:           row.GroupKey,
+			TotalSeconds:    row.TotalSeconds,
+			TrackedSeconds:  row.TotalSeconds - row.AdjustedSeconds,
+			AdjustedSeconds: row.AdjustedSeconds,
+			TaskCount:       row.TaskCount,
+			AverageSeconds:  row.AverageSeconds,
+		})
+	}
+
+	return reportResponse{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Period:    req.Period,
+		GroupBy:   req.GroupBy,
+		Timezone:  loc.String(),
+		Entries:   entries,
+	}, nil
+}
+
+func (cfg *config) WSOnReportFetch(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("report_fetch").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data reportRequest `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return sendError(c, "invalid_request", "Invalid report request", 400)
+	}
+
+	report, err := cfg.buildReport(ctx, client.User, payload.Data)
+	if err != nil {
+		if _, ok := err.(reportRequestError); ok {
+			return sendError(c, "invalid_request", err.Error(), 400)
+		}
+		logDBError("Failed to build report for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to build report", 500)
+	}
+
+	return cfg.WSClientManager.SendToClient(ctx, "report_fetch", SID, report)
+}
+
+// ReportsApiHandler serves GET /api/reports. Callers identify themselves with the
+// X-Google-UID header; filters are the same as report_fetch, passed as query params.
+func (cfg *config) ReportsApiHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	writeError := func(status int, message string) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"error": message})
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	googleUID := r.Header.Get("X-Google-UID")
+	if googleUID == "" {
+		writeError(http.StatusUnauthorized, "X-Google-UID header is required")
+		return
+	}
+
+	user, err := cfg.DB.GetUserByGoogleUID(r.Context(), sql.NullString{String: googleUID, Valid: true})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			writeError(http.StatusUnauthorized, "unknown user")
+			return
+		}
+		log.Printf("ReportsApiHandler: failed to load user: %v", err)
+		writeError(http.StatusInternalServerError, "database error")
+		return
+	}
+
+	query := r.URL.Query()
+	report, err := cfg.buildReport(r.Context(), user, reportRequest{
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+		Period:    query.Get("period"),
+		GroupBy:   query.Get("group_by"),
+	})
+	if err != nil {
+		if _, ok := err.(reportRequestError); ok {
+			writeError(http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Printf("ReportsApiHandler: failed to build report: %v", err)
+		writeError(http.StatusInternalServerError, "database error")
+		return
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
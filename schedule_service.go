@@ -275,11 +275,14 @@ func (s *ScheduleService) ensureTaskForOccurrence(ctx context.Context, sch datab
 		Priority:          sql.NullInt32{Valid: false},
 		DueAt:             dueAt,
 		ShowBeforeDueTime: sch.ShowBeforeMinutes,
+		EstimateMinutes:   sch.EstimateMinutes,
 	})
 	if err != nil {
 		return err
 	}
 
+	recordTaskRevision(ctx, s.queries, uuid.NullUUID{}, "create", nil, task)
+
 	// Link task to occurrence
 	err = s.queries.LinkTaskToOccurrence(ctx, database.LinkTaskToOccurrenceParams{
 		OccurrenceID: occ.ID,
@@ -353,7 +356,7 @@ func (s *ScheduleService) createNotificationJobs(ctx context.Context, sch databa
 		err := s.queries.UpsertNotificationJob(ctx, database.UpsertNotificationJobParams{
 			UserID:        sch.UserID,
 			ScheduleID:    uuid.NullUUID{UUID: sch.ID, Valid: true},
-			OccurrenceID:  occ.ID,
+			OccurrenceID:  uuid.NullUUID{UUID: occ.ID, Valid: true},
 			OffsetMinutes: int32(off),
 			PlannedSendAt: sendAt,
 			Payload:       payloadJSON,
@@ -415,7 +418,7 @@ func (s *ScheduleService) createTaskNotificationJobs(ctx context.Context, sch da
 		err := s.queries.UpsertNotificationJob(ctx, database.UpsertNotificationJobParams{
 			UserID:        sch.UserID,
 			ScheduleID:    uuid.NullUUID{UUID: sch.ID, Valid: true},
-			OccurrenceID:  occ.ID,
+			OccurrenceID:  uuid.NullUUID{UUID: occ.ID, Valid: true},
 			OffsetMinutes: int32(off),
 			PlannedSendAt: sendAt,
 			Payload:       payloadJSON,
@@ -468,7 +471,7 @@ func (s *ScheduleService) createReminderNotificationJobs(ctx context.Context, sc
 	err := s.queries.UpsertNotificationJob(ctx, database.UpsertNotificationJobParams{
 		UserID:        sch.UserID,
 		ScheduleID:    uuid.NullUUID{UUID: sch.ID, Valid: true},
-		OccurrenceID:  occ.ID,
+		OccurrenceID:  uuid.NullUUID{UUID: occ.ID, Valid: true},
 		OffsetMinutes: 0,
 		PlannedSendAt: sendAt,
 		Payload:       payloadJSON,
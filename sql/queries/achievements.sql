@@ -0,0 +1,57 @@
+-- name: CreateAchievement :one
+INSERT INTO achievements (id, user_id, code, key, title, description, payload)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, code, key) DO NOTHING
+RETURNING *;
+
+-- name: GetAchievementsByUser :many
+SELECT * FROM achievements
+WHERE user_id = $1
+ORDER BY earned_at DESC;
+
+-- name: GetAllUsers :many
+SELECT * FROM users;
+
+-- name: CountCompletedTasksByUser :one
+SELECT COUNT(*)::bigint AS completed
+FROM tasks t
+WHERE t.user_id = $1
+  AND t.is_completed = TRUE
+  AND NOT EXISTS (SELECT 1 FROM tasks c WHERE c.continuation_of = t.id);
+
+-- name: GetScheduleCompletion :many
+WITH RECURSIVE chain AS (
+	SELECT o.id AS occurrence_id, l.task_id
+	FROM occurrences o
+	JOIN schedules s ON s.id = o.schedule_id
+	JOIN task_links l ON l.occurrence_id = o.id
+	WHERE s.user_id = @user_id
+	  AND o.occurs_at >= @start_at::timestamptz
+	  AND o.occurs_at < @end_at::timestamptz
+	UNION ALL
+	SELECT chain.occurrence_id, t.id
+	FROM chain
+	JOIN tasks t ON t.continuation_of = chain.task_id
+),
+done AS (
+	SELECT chain.occurrence_id
+	FROM chain
+	JOIN tasks t ON t.id = chain.task_id
+	WHERE t.is_completed = TRUE
+	  AND NOT EXISTS (SELECT 1 FROM tasks c WHERE c.continuation_of = t.id)
+	GROUP BY chain.occurrence_id
+)
+SELECT
+	s.id AS schedule_id,
+	s.title,
+	COUNT(o.id)::bigint AS occurrences,
+	COUNT(done.occurrence_id)::bigint AS completed
+FROM schedules s
+JOIN occurrences o ON o.schedule_id = s.id
+LEFT JOIN done ON done.occurrence_id = o.id
+WHERE s.user_id = @user_id
+  AND s.kind = 'task'
+  AND s.rrule IS NOT NULL
+  AND o.occurs_at >= @start_at::timestamptz
+  AND o.occurs_at < @end_at::timestamptz
+GROUP BY s.id, s.title;
@@ -0,0 +1,31 @@
+-- name: CreateFocusSession :one
+INSERT INTO focus_sessions (id, user_id, task_id, phase, work_minutes, break_minutes, cycles_planned, phase_ends_at)
+VALUES ($1, $2, $3, 'work', $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetRunningFocusSessionByUser :one
+SELECT * FROM focus_sessions
+WHERE user_id = $1 AND status = 'running';
+
+-- name: GetDueFocusSessions :many
+SELECT * FROM focus_sessions
+WHERE status = 'running' AND phase_ends_at <= NOW()
+ORDER BY phase_ends_at ASC;
+
+-- name: AdvanceFocusSession :one
+UPDATE focus_sessions
+SET
+	phase = $2,
+	cycles_completed = $3,
+	phase_ends_at = $4
+WHERE id = $1 AND status = 'running'
+RETURNING *;
+
+-- name: EndFocusSession :one
+UPDATE focus_sessions
+SET
+	status = $2,
+	cycles_completed = $3,
+	ended_at = NOW()
+WHERE id = $1 AND status = 'running'
+RETURNING *;
@@ -0,0 +1,72 @@
+-- name: CreateGoal :one
+INSERT INTO goals (id, user_id, scope, scope_value, period, target_minutes, direction)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: UpdateGoal :one
+UPDATE goals
+SET
+	scope = $2,
+	scope_value = $3,
+	period = $4,
+	target_minutes = $5,
+	direction = $6,
+	last_achieved_period_start = NULL,
+	updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteGoal :exec
+DELETE FROM goals
+WHERE id = $1;
+
+-- name: GetGoalByID :one
+SELECT * FROM goals
+WHERE id = $1;
+
+-- name: GetGoalsByUser :many
+SELECT * FROM goals
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetUsersWithGoals :many
+SELECT * FROM users
+WHERE id IN (SELECT DISTINCT user_id FROM goals);
+
+-- name: MarkGoalAchieved :one
+UPDATE goals
+SET last_achieved_period_start = @period_start
+WHERE id = @id
+  AND (last_achieved_period_start IS NULL OR last_achieved_period_start < @period_start)
+RETURNING *;
+
+-- name: GetTrackedSecondsForScope :one
+SELECT COALESCE(SUM(x.seconds), 0)::bigint AS tracked_seconds
+FROM (
+	SELECT EXTRACT(EPOCH FROM t.duration::interval)::bigint AS seconds, t.category, t.tags
+	FROM tasks t
+	WHERE t.user_id = @user_id
+	  AND t.is_completed = TRUE
+	  AND t.completed_at >= @start_at::timestamptz
+	  AND t.completed_at < @end_at::timestamptz
+	UNION ALL
+	SELECT EXTRACT(EPOCH FROM s.duration::interval)::bigint, t.category, t.tags
+	FROM task_segments s
+	JOIN tasks t ON t.id = s.task_id
+	WHERE s.user_id = @user_id
+	  AND s.ended_at >= @start_at::timestamptz
+	  AND s.ended_at < @end_at::timestamptz
+	UNION ALL
+	SELECT EXTRACT(EPOCH FROM t.duration::interval)::bigint
+		+ CASE WHEN t.is_active AND COALESCE(t.toggled_at, 0) > 0
+			THEN GREATEST((@now_ms::bigint - t.toggled_at) / 1000, 0)
+			ELSE 0
+		END,
+		t.category, t.tags
+	FROM tasks t
+	WHERE t.user_id = @user_id
+	  AND t.is_completed = FALSE
+	  AND @include_open::boolean
+) x
+WHERE (@scope::text = 'category' AND x.category = @scope_value::text)
+   OR (@scope::text = 'tag' AND @scope_value::text = ANY(x.tags));
@@ -0,0 +1,39 @@
+-- name: CreateIdlePeriod :one
+INSERT INTO idle_periods (id, task_id, user_id, idle_from, idle_until)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ResolveIdlePeriod :one
+UPDATE idle_periods
+SET
+	status = $2,
+	resolved_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: GetIdlePeriodByID :one
+SELECT * FROM idle_periods
+WHERE id = $1;
+
+-- name: GetIdleRunningTasks :many
+SELECT t.id AS task_id, u.last_activity_at
+FROM tasks t
+JOIN users u ON u.id = t.user_id
+WHERE t.is_active = TRUE
+  AND t.is_completed = FALSE
+  AND u.idle_threshold_minutes > 0
+  AND u.last_activity_at IS NOT NULL
+  AND GREATEST(u.last_activity_at, to_timestamp(COALESCE(t.toggled_at, 0) / 1000.0)) < NOW() - make_interval(mins => u.idle_threshold_minutes)
+  AND NOT EXISTS (
+	SELECT 1 FROM focus_sessions f
+	WHERE f.user_id = u.id AND f.status = 'running'
+  );
+
+-- name: GetUntrackedIdlePeriodsInRange :many
+-- Idle time that was not kept on the task
+SELECT * FROM idle_periods
+WHERE user_id = @user_id
+	AND status <> 'kept'
+	AND idle_until > @start_at
+	AND idle_from < @end_at
+ORDER BY idle_from ASC;
@@ -28,7 +28,7 @@ UPDATE notification_jobs j
 SET sent_at = now()
 FROM due
 WHERE j.id = due.id
-RETURNING j.id, j.user_id, j.schedule_id, j.occurrence_id, j.offset_minutes, j.payload, j.planned_send_at;
+RETURNING j.id, j.user_id, j.schedule_id, j.occurrence_id, j.task_id, j.offset_minutes, j.payload, j.planned_send_at;
 
 -- name: GetNotificationJobsByUser :many
 SELECT * FROM notification_jobs 
@@ -40,3 +40,34 @@ SELECT * FROM notification_jobs
 WHERE sent_at IS NULL 
   AND canceled_at IS NULL
 ORDER BY planned_send_at ASC;
+
+-- name: UpsertTaskNotificationJob :exec
+INSERT INTO notification_jobs (user_id, task_id, offset_minutes, planned_send_at, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (task_id, offset_minutes) WHERE task_id IS NOT NULL AND sent_at IS NULL AND canceled_at IS NULL
+DO UPDATE SET planned_send_at = EXCLUDED.planned_send_at,
+              payload = EXCLUDED.payload;
+
+-- name: CancelPendingJobsForTask :exec
+UPDATE notification_jobs
+SET canceled_at = now()
+WHERE (
+    task_id = @task_id
+    OR occurrence_id IN (SELECT occurrence_id FROM task_links WHERE task_links.task_id = @task_id)
+  )
+  AND sent_at IS NULL
+  AND canceled_at IS NULL;
+
+-- name: UpsertProjectNotificationJob :exec
+INSERT INTO notification_jobs (user_id, project_id, offset_minutes, planned_send_at, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (project_id, offset_minutes) WHERE project_id IS NOT NULL AND sent_at IS NULL AND canceled_at IS NULL
+DO UPDATE SET planned_send_at = EXCLUDED.planned_send_at,
+              payload = EXCLUDED.payload;
+
+-- name: CancelPendingJobsForProject :exec
+UPDATE notification_jobs
+SET canceled_at = now()
+WHERE project_id = $1
+  AND sent_at IS NULL
+  AND canceled_at IS NULL;
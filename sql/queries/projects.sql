@@ -0,0 +1,97 @@
+-- name: CreateProject :one
+INSERT INTO projects (id, user_id, name, description, category, deadline, status, budget_minutes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetProjectByID :one
+SELECT * FROM projects
+WHERE id = $1;
+
+-- name: GetProjectsByUser :many
+SELECT * FROM projects
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdateProject :one
+UPDATE projects
+SET
+	name = $2,
+	description = $3,
+	category = $4,
+	deadline = $5,
+	status = $6,
+	budget_minutes = $7,
+	-- A new budget starts its alerts over
+	budget_warned_at = CASE WHEN budget_minutes IS DISTINCT FROM $7 THEN NULL ELSE budget_warned_at END,
+	budget_exhausted_at = CASE WHEN budget_minutes IS DISTINCT FROM $7 THEN NULL ELSE budget_exhausted_at END,
+	updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteProject :exec
+DELETE FROM projects
+WHERE id = $1;
+
+-- name: GetProjectRollups :many
+-- Tracked time counts every task of the project, the running segment and the days
+-- closed out by a continue rollover. A task a clone rollover carried over to a new
+-- copy is not done, its time still counts
+SELECT
+	p.id AS project_id,
+	COUNT(t.id) FILTER (WHERE t.is_completed = FALSE) AS open_count,
+	COUNT(t.id) FILTER (
+		WHERE t.is_completed = TRUE
+		  AND NOT EXISTS (SELECT 1 FROM tasks c WHERE c.continuation_of = t.id)
+	) AS done_count,
+	(
+		COALESCE(SUM(
+			EXTRACT(EPOCH FROM t.duration::interval)::bigint
+			+ CASE WHEN t.is_active AND COALESCE(t.toggled_at, 0) > 0
+				THEN GREATEST((@now_ms::bigint - t.toggled_at) / 1000, 0)
+				ELSE 0
+			END
+		), 0)
+		+ COALESCE((
+			SELECT SUM(EXTRACT(EPOCH FROM s.duration::interval))
+			FROM task_segments s
+			JOIN tasks st ON st.id = s.task_id
+			WHERE st.project_id = p.id
+		), 0)
+	)::bigint AS tracked_seconds
+FROM projects p
+LEFT JOIN tasks t ON t.project_id = p.id
+WHERE p.user_id = @user_id
+GROUP BY p.id;
+
+-- name: GetProjectTrackedSeconds :one
+SELECT (
+	COALESCE((
+		SELECT SUM(
+			EXTRACT(EPOCH FROM t.duration::interval)::bigint
+			+ CASE WHEN t.is_active AND COALESCE(t.toggled_at, 0) > 0
+				THEN GREATEST((@now_ms::bigint - t.toggled_at) / 1000, 0)
+				ELSE 0
+			END
+		)
+		FROM tasks t
+		WHERE t.project_id = @project_id
+	), 0)
+	+ COALESCE((
+		SELECT SUM(EXTRACT(EPOCH FROM s.duration::interval))
+		FROM task_segments s
+		JOIN tasks st ON st.id = s.task_id
+		WHERE st.project_id = @project_id
+	), 0)
+)::bigint AS tracked_seconds;
+
+-- name: MarkProjectBudgetWarned :exec
+UPDATE projects
+SET budget_warned_at = COALESCE(budget_warned_at, $2)
+WHERE id = $1;
+
+-- name: MarkProjectBudgetExhausted :exec
+UPDATE projects
+SET
+	budget_warned_at = COALESCE(budget_warned_at, $2),
+	budget_exhausted_at = COALESCE(budget_exhausted_at, $2)
+WHERE id = $1;
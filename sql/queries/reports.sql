@@ -0,0 +1,68 @@
+-- name: GetTimeReport :many
+-- Manual time adjustments are counted in the entry whose time they landed in: the
+-- first segment closed after them, or the completed task for those after the last one.
+-- A revert lands in the entry of the adjustment it undoes. Booked intervals, and the
+-- reverts of them, are taken out of that entry and counted when the interval started
+-- instead
+WITH segments AS (
+	SELECT s.task_id, s.ended_at, s.duration,
+		LAG(s.ended_at) OVER (PARTITION BY s.task_id ORDER BY s.ended_at) AS previous_ended_at
+	FROM task_segments s
+	WHERE s.user_id = @user_id
+),
+adjustments AS (
+	SELECT a.task_id, a.seconds, COALESCE(r.created_at, a.created_at) AS created_at,
+		COALESCE(a.started_at, r.started_at) AS booked_at
+	FROM time_adjustments a
+	LEFT JOIN time_adjustments r ON r.id = a.reverts_id
+	WHERE a.user_id = @user_id
+),
+windows AS (
+	SELECT t.id AS task_id, t.completed_at AS spent_at, t.duration::interval AS duration,
+		COALESCE((SELECT MAX(s.ended_at) FROM segments s WHERE s.task_id = t.id), '-infinity') AS window_start,
+		'infinity'::timestamptz AS window_end,
+		t.category, t.tags, t.priority
+	FROM tasks t
+	WHERE t.user_id = @user_id AND t.is_completed = TRUE AND t.completed_at IS NOT NULL
+	UNION ALL
+	SELECT s.task_id, s.ended_at, s.duration::interval,
+		COALESCE(s.previous_ended_at, '-infinity'), s.ended_at,
+		t.category, t.tags, t.priority
+	FROM segments s
+	JOIN tasks t ON t.id = s.task_id
+),
+entries AS (
+	SELECT w.task_id, w.spent_at,
+		w.duration - make_interval(secs => COALESCE(SUM(a.seconds) FILTER (WHERE a.booked_at IS NOT NULL), 0)::double precision) AS spent,
+		COALESCE(SUM(a.seconds) FILTER (WHERE a.booked_at IS NULL), 0) AS adjusted,
+		w.category, w.tags, w.priority
+	FROM windows w
+	LEFT JOIN adjustments a ON a.task_id = w.task_id
+		AND a.created_at >= w.window_start
+		AND a.created_at < w.window_end
+	GROUP BY w.task_id, w.spent_at, w.window_start, w.duration, w.category, w.tags, w.priority
+	UNION ALL
+	SELECT a.task_id, a.booked_at, make_interval(secs => a.seconds::double precision), a.seconds,
+		t.category, t.tags, t.priority
+	FROM adjustments a
+	JOIN tasks t ON t.id = a.task_id
+	WHERE a.booked_at IS NOT NULL
+)
+SELECT
+	(date_trunc(@period::text, (e.spent_at AT TIME ZONE @timezone::text) - make_interval(hours => @rollover_hour::int))
+		+ make_interval(hours => @rollover_hour::int))::timestamp AS period_start,
+	(CASE @group_by::text
+		WHEN 'category' THEN e.category
+		WHEN 'priority' THEN COALESCE(e.priority::text, 'none')
+		ELSE COALESCE(tag.name, 'untagged')
+	END)::text AS group_key,
+	SUM(EXTRACT(EPOCH FROM e.spent))::bigint AS total_seconds,
+	SUM(e.adjusted)::bigint AS adjusted_seconds,
+	COUNT(DISTINCT e.task_id)::bigint AS task_count,
+	(SUM(EXTRACT(EPOCH FROM e.spent)) / COUNT(DISTINCT e.task_id))::bigint AS average_seconds
+FROM entries e
+LEFT JOIN LATERAL unnest(CASE WHEN @group_by::text = 'tag' THEN e.tags ELSE ARRAY[]::text[] END) AS tag(name) ON TRUE
+WHERE e.spent_at >= @start_at::timestamptz
+	AND e.spent_at < @end_at::timestamptz
+GROUP BY 1, 2
+ORDER BY 1, 2;
@@ -1,7 +1,7 @@
 -- name: CreateSchedule :one
 INSERT INTO schedules (user_id, kind, title, tz, start_local, rrule, until_local,
-                       show_before_minutes, notify_offsets_min, muted_offsets_min, category)
-VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, 0), COALESCE($9, '{2880,1440,720,360,180}')::integer[], COALESCE($10, '{}')::integer[], COALESCE($11, 'Life'))
+                       show_before_minutes, notify_offsets_min, muted_offsets_min, category, estimate_minutes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, 0), COALESCE($9, '{2880,1440,720,360,180}')::integer[], COALESCE($10, '{}')::integer[], COALESCE($11, 'Life'), $12)
 RETURNING *;
 
 -- name: GetActiveSchedules :many
@@ -17,7 +17,7 @@ SELECT * FROM schedules WHERE id = $1;
 UPDATE schedules 
 SET title = $2, tz = $3, start_local = $4, rrule = $5, until_local = $6,
     show_before_minutes = $7, notify_offsets_min = $8, muted_offsets_min = $9,
-    category = $10, updated_at = NOW()
+    category = $10, estimate_minutes = $11, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -32,3 +32,22 @@ UPDATE schedules SET last_materialized_until = $2, updated_at = NOW() WHERE id =
 
 -- name: DeleteSchedule :exec
 DELETE FROM schedules WHERE id = $1;
+
+-- name: GetTaskScheduleOffsets :one
+-- The schedule a task came from, walking back through rollover clones to the linked occurrence
+WITH RECURSIVE lineage AS (
+    SELECT t.id, t.continuation_of, 0 AS depth
+    FROM tasks t
+    WHERE t.id = $1
+    UNION ALL
+    SELECT p.id, p.continuation_of, lineage.depth + 1
+    FROM lineage
+    JOIN tasks p ON p.id = lineage.continuation_of
+)
+SELECT s.notify_offsets_min, s.muted_offsets_min
+FROM lineage
+JOIN task_links l ON l.task_id = lineage.id
+JOIN occurrences o ON o.id = l.occurrence_id
+JOIN schedules s ON s.id = o.schedule_id
+ORDER BY lineage.depth
+LIMIT 1;
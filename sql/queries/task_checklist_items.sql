@@ -0,0 +1,90 @@
+-- name: CreateChecklistItem :one
+INSERT INTO task_checklist_items (id, task_id, user_id, text, position)
+VALUES ($1, $2, $3, $4, (
+	SELECT COALESCE(MAX(position), 0) + 1024 FROM task_checklist_items WHERE task_id = $2
+))
+RETURNING *;
+
+-- name: GetChecklistItemByID :one
+SELECT * FROM task_checklist_items
+WHERE id = $1;
+
+-- name: GetChecklistItemsByTask :many
+SELECT * FROM task_checklist_items
+WHERE task_id = $1
+ORDER BY position ASC, created_at ASC;
+
+-- name: GetActiveChecklistItemsByUser :many
+SELECT i.* FROM task_checklist_items i
+JOIN tasks t ON t.id = i.task_id
+WHERE i.user_id = $1 AND t.is_completed = FALSE
+ORDER BY i.task_id, i.position ASC, i.created_at ASC;
+
+-- name: UpdateChecklistItemText :one
+UPDATE task_checklist_items
+SET
+	text = $2,
+	updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetChecklistItemChecked :one
+UPDATE task_checklist_items
+SET
+	checked = $2,
+	checked_at = CASE WHEN $2 THEN NOW() ELSE NULL END,
+	updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetChecklistItemPosition :one
+UPDATE task_checklist_items
+SET
+	position = $2,
+	updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: RenumberChecklistItems :exec
+UPDATE task_checklist_items
+SET position = ranked.position
+FROM (
+	SELECT id, ROW_NUMBER() OVER (ORDER BY position ASC, created_at ASC) * 1024 AS position
+	FROM task_checklist_items
+	WHERE task_id = $1
+) ranked
+WHERE task_checklist_items.id = ranked.id;
+
+-- name: DeleteChecklistItem :exec
+DELETE FROM task_checklist_items
+WHERE id = $1;
+
+-- name: CopyChecklistItems :exec
+INSERT INTO task_checklist_items (id, task_id, user_id, text, checked, checked_at, position)
+SELECT
+	uuid_generate_v4(),
+	@target_task_id,
+	i.user_id,
+	i.text,
+	CASE WHEN @keep_checked::boolean THEN i.checked ELSE FALSE END,
+	CASE WHEN @keep_checked::boolean THEN i.checked_at ELSE NULL END,
+	i.position
+FROM task_checklist_items i
+WHERE i.task_id = @source_task_id;
+
+-- name: RefreshTaskChecklistCounts :one
+UPDATE tasks
+SET
+	checklist_total = (SELECT COUNT(*) FROM task_checklist_items i WHERE i.task_id = tasks.id),
+	checklist_checked = (SELECT COUNT(*) FROM task_checklist_items i WHERE i.task_id = tasks.id AND i.checked = TRUE)
+WHERE id = $1
+RETURNING *;
+
+-- name: MoveChecklistItems :exec
+UPDATE task_checklist_items
+SET task_id = @new_task_id,
+	position = position + (
+		SELECT COALESCE(MAX(position), 0) FROM task_checklist_items WHERE task_id = @new_task_id
+	),
+	updated_at = NOW()
+WHERE task_id = @old_task_id;
@@ -0,0 +1,89 @@
+-- name: AddTaskDependency :exec
+INSERT INTO task_dependencies (task_id, depends_on_task_id, user_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (task_id, depends_on_task_id) DO NOTHING;
+
+-- name: RemoveTaskDependency :exec
+DELETE FROM task_dependencies
+WHERE task_id = $1 AND depends_on_task_id = $2;
+
+-- name: GetTaskDependenciesByUser :many
+SELECT * FROM task_dependencies
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetDependentTaskIDs :many
+SELECT task_id FROM task_dependencies WHERE depends_on_task_id = $1;
+
+-- name: HasDependencyPath :one
+WITH RECURSIVE chain(task_id) AS (
+	SELECT d.depends_on_task_id
+	FROM task_dependencies d
+	WHERE d.task_id = @from_task_id
+	UNION
+	SELECT d.depends_on_task_id
+	FROM task_dependencies d
+	JOIN chain ON d.task_id = chain.task_id
+)
+SELECT EXISTS (
+	SELECT 1 FROM chain WHERE chain.task_id = @to_task_id
+) AS exists;
+
+-- name: ReassignTaskDependencies :exec
+WITH moved AS (
+	DELETE FROM task_dependencies
+	WHERE task_id = @old_task_id OR depends_on_task_id = @old_task_id
+	RETURNING task_id, depends_on_task_id, user_id, created_at
+)
+INSERT INTO task_dependencies (task_id, depends_on_task_id, user_id, created_at)
+SELECT
+	CASE WHEN moved.task_id = @old_task_id THEN @new_task_id::uuid ELSE moved.task_id END,
+	CASE WHEN moved.depends_on_task_id = @old_task_id THEN @new_task_id::uuid ELSE moved.depends_on_task_id END,
+	moved.user_id,
+	moved.created_at
+FROM moved
+WHERE NOT (moved.task_id = @new_task_id::uuid AND moved.depends_on_task_id = @old_task_id)
+  AND NOT (moved.task_id = @old_task_id AND moved.depends_on_task_id = @new_task_id::uuid)
+ON CONFLICT (task_id, depends_on_task_id) DO NOTHING;
+
+-- name: RefreshTaskBlocked :one
+UPDATE tasks
+SET blocked = EXISTS (
+	SELECT 1
+	FROM task_dependencies d
+	JOIN tasks blocker ON blocker.id = d.depends_on_task_id
+	WHERE d.task_id = tasks.id
+	  AND blocker.is_completed = FALSE
+)
+WHERE id = $1
+RETURNING *;
+
+-- name: UnblockDependentTasks :many
+UPDATE tasks
+SET blocked = FALSE
+WHERE blocked = TRUE
+  AND id IN (
+	SELECT d.task_id FROM task_dependencies d WHERE d.depends_on_task_id = $1
+  )
+  AND NOT EXISTS (
+	SELECT 1
+	FROM task_dependencies d
+	JOIN tasks blocker ON blocker.id = d.depends_on_task_id
+	WHERE d.task_id = tasks.id
+	  AND blocker.is_completed = FALSE
+  )
+RETURNING *;
+
+-- name: CopyTaskDependencies :exec
+INSERT INTO task_dependencies (task_id, depends_on_task_id, user_id, created_at)
+SELECT
+	CASE WHEN d.task_id = @old_task_id THEN @new_task_id::uuid ELSE d.task_id END,
+	CASE WHEN d.depends_on_task_id = @old_task_id THEN @new_task_id::uuid ELSE d.depends_on_task_id END,
+	d.user_id,
+	d.created_at
+FROM task_dependencies d
+WHERE d.task_id = @old_task_id OR d.depends_on_task_id = @old_task_id
+ON CONFLICT (task_id, depends_on_task_id) DO NOTHING;
+
+-- name: LockUserTaskDependencies :exec
+SELECT pg_advisory_xact_lock(hashtextextended('task_dependencies:' || @user_id::text, 0));
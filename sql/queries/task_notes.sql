@@ -0,0 +1,50 @@
+-- name: CreateTaskNote :one
+INSERT INTO task_notes (id, task_id, user_id, body, device_sid, device)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTaskNoteByID :one
+SELECT * FROM task_notes
+WHERE id = $1;
+
+-- name: GetTaskNotesByTask :many
+SELECT * FROM task_notes
+WHERE task_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdateTaskNoteBody :one
+UPDATE task_notes
+SET body = $2,
+	edited_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTaskNote :exec
+DELETE FROM task_notes
+WHERE id = $1;
+
+-- name: SearchTaskNotes :many
+SELECT n.id, n.task_id, n.user_id, n.body, n.device_sid, n.device, n.created_at, n.edited_at,
+	t.title AS task_title, t.is_completed AS task_is_completed
+FROM task_notes n
+JOIN tasks t ON t.id = n.task_id
+WHERE n.user_id = @user_id
+	AND n.body ILIKE @search_query::text
+ORDER BY n.created_at DESC
+LIMIT @limit_val;
+
+-- name: MoveTaskNotes :exec
+UPDATE task_notes
+SET task_id = @new_task_id
+WHERE task_id = @old_task_id;
+
+-- name: CopyTaskNotes :exec
+INSERT INTO task_notes (id, task_id, user_id, body, device_sid, device, created_at, edited_at)
+SELECT uuid_generate_v4(), @target_task_id, n.user_id, n.body, n.device_sid, n.device, n.created_at, n.edited_at
+FROM task_notes n
+WHERE n.task_id = @source_task_id;
+
+-- name: GetTaskNotesByTaskIDs :many
+SELECT * FROM task_notes
+WHERE task_id = ANY(@task_ids::uuid[])
+ORDER BY task_id, created_at ASC;
@@ -0,0 +1,63 @@
+-- name: CreateTaskRevision :one
+INSERT INTO task_revisions (
+	id,
+	task_id,
+	user_id,
+	actor_sid,
+	action,
+	changes,
+	snapshot
+) VALUES (
+	$1,
+	$2,
+	$3,
+	$4,
+	$5,
+	$6,
+	$7
+) RETURNING *;
+
+-- name: GetTaskRevisionByID :one
+SELECT * FROM task_revisions WHERE id = $1;
+
+-- name: ListTaskRevisions :many
+SELECT *
+FROM task_revisions
+WHERE task_id = $1 AND user_id = $2
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetTaskRevisionsInRange :many
+-- The user's revisions in the range, plus the last one before it and the first one
+-- after it for the tasks that could have been running across either edge: those
+-- changed since the range started, and those running now. Older history is not read.
+WITH candidates AS (
+	SELECT task_revisions.task_id
+	FROM task_revisions
+	WHERE task_revisions.user_id = @user_id AND task_revisions.created_at >= @start_at
+	UNION
+	SELECT tasks.id
+	FROM tasks
+	WHERE tasks.user_id = @user_id AND tasks.is_active = TRUE AND tasks.is_completed = FALSE
+)
+SELECT earlier.* FROM candidates
+CROSS JOIN LATERAL (
+	SELECT r.*
+	FROM task_revisions r
+	WHERE r.task_id = candidates.task_id AND r.user_id = @user_id AND r.created_at < @start_at
+	ORDER BY r.created_at DESC, r.id DESC
+	LIMIT 1
+) earlier
+UNION ALL
+SELECT *
+FROM task_revisions
+WHERE user_id = @user_id AND created_at >= @start_at AND created_at < @end_at
+UNION ALL
+SELECT later.* FROM candidates
+CROSS JOIN LATERAL (
+	SELECT r.*
+	FROM task_revisions r
+	WHERE r.task_id = candidates.task_id AND r.user_id = @user_id AND r.created_at >= @end_at
+	ORDER BY r.created_at ASC, r.id ASC
+	LIMIT 1
+) later
+ORDER BY task_id, created_at, id;
@@ -0,0 +1,60 @@
+-- name: CreateTaskSegment :one
+INSERT INTO task_segments (id, task_id, user_id, day_start, ended_at, duration)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTaskSegmentsByUUID :many
+SELECT s.id, s.task_id, s.user_id, s.day_start, s.ended_at, s.duration, s.created_at, t.title, t.description, t.category, t.tags
+FROM task_segments s
+JOIN tasks t ON t.id = s.task_id
+WHERE s.user_id = @user_id
+	AND (
+	  sqlc.narg(start_date)::timestamptz IS NULL OR s.ended_at >= sqlc.narg(start_date)::timestamptz
+	)
+	AND (
+	  sqlc.narg(end_date)::timestamptz IS NULL OR s.ended_at <= sqlc.narg(end_date)::timestamptz
+	)
+	AND (
+		cardinality(@tags::text[]) = 0
+		OR EXISTS (
+			SELECT 1
+			FROM unnest(@tags::text[]) AS tag_filter
+			WHERE tag_filter ILIKE ANY (t.tags)
+		)
+	)
+	AND (
+		sqlc.narg(search_query)::text IS NULL OR t.title ILIKE sqlc.narg(search_query)::text
+		OR EXISTS (
+			SELECT 1 FROM task_notes n
+			WHERE n.task_id = t.id AND n.body ILIKE sqlc.narg(search_query)::text
+		)
+	)
+	AND (
+		sqlc.narg(category)::text IS NULL OR t.category = sqlc.narg(category)::text
+	)
+	AND (
+		sqlc.narg(overdue)::boolean IS NULL OR (t.overdue_since IS NOT NULL) = sqlc.narg(overdue)::boolean
+	)
+	AND (
+		sqlc.narg(state_id)::uuid IS NULL OR t.state_id = sqlc.narg(state_id)::uuid
+	)
+ORDER BY s.day_start ASC, t.created_at ASC;
+
+-- name: MoveTaskSegments :exec
+UPDATE task_segments
+SET task_id = @new_task_id
+WHERE task_id = @old_task_id;
+
+-- name: GetTaskSegmentEndedAfter :one
+-- The closed out day that covers a moment, the first one to end after it
+SELECT * FROM task_segments
+WHERE task_id = $1 AND ended_at > $2
+ORDER BY ended_at ASC
+LIMIT 1
+FOR UPDATE;
+
+-- name: SetTaskSegmentDuration :one
+UPDATE task_segments
+SET duration = $2
+WHERE id = $1
+RETURNING *;
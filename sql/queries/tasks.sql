@@ -28,17 +28,40 @@ WHERE user_id = @user_id
 	)
 	  AND (
 		sqlc.narg(search_query)::text IS NULL OR title ILIKE sqlc.narg(search_query)::text
+		OR EXISTS (
+			SELECT 1 FROM task_notes n
+			WHERE n.task_id = tasks.id AND n.body ILIKE sqlc.narg(search_query)::text
+		)
 	  )
 	  AND (
 		sqlc.narg(category)::text IS NULL OR category = sqlc.narg(category)::text
 	  )
+	  AND (
+		sqlc.narg(overdue)::boolean IS NULL OR (overdue_since IS NOT NULL) = sqlc.narg(overdue)::boolean
+	  )
+	  AND (
+		sqlc.narg(state_id)::uuid IS NULL OR state_id = sqlc.narg(state_id)::uuid
+	  )
 ORDER BY created_at ASC;
 
 -- name: GetActiveTaskByUUID :many
 SELECT * 
 FROM tasks
+WHERE user_id = @user_id AND is_completed = FALSE
+	AND (hidden_until IS NULL OR hidden_until <= NOW())
+	AND (
+		sqlc.narg(overdue)::boolean IS NULL OR (overdue_since IS NOT NULL) = sqlc.narg(overdue)::boolean
+	)
+	AND (
+		sqlc.narg(state_id)::uuid IS NULL OR state_id = sqlc.narg(state_id)::uuid
+	)
+ORDER BY sort_position ASC, created_at ASC;
+
+-- name: GetOpenTasksByUser :many
+SELECT *
+FROM tasks
 WHERE user_id = $1 AND is_completed = FALSE
-ORDER BY created_at ASC;
+ORDER BY sort_position ASC, created_at ASC;
 
 -- name: GetTasksDueForVisibility :many
 SELECT * 
@@ -49,6 +72,7 @@ WHERE user_id = $1
   AND show_before_due_time IS NOT NULL
   AND due_at - INTERVAL '1 minute' * show_before_due_time <= NOW() AT TIME ZONE 'UTC'
   AND due_at - INTERVAL '1 minute' * show_before_due_time > NOW() AT TIME ZONE 'UTC' - INTERVAL '1 minute'
+  AND (hidden_until IS NULL OR hidden_until <= NOW())
 ORDER BY due_at ASC;
 
 -- name: GetTasksDueForNotifications :many
@@ -69,6 +93,7 @@ WHERE is_completed = FALSE
   AND show_before_due_time IS NOT NULL
   AND due_at - INTERVAL '1 minute' * show_before_due_time <= NOW() AT TIME ZONE 'UTC'
   AND due_at - INTERVAL '1 minute' * show_before_due_time > NOW() AT TIME ZONE 'UTC' - INTERVAL '1 minute'
+  AND (hidden_until IS NULL OR hidden_until <= NOW())
 ORDER BY due_at ASC;
 
 -- name: GetUpcomingTasksForNotifications :many
@@ -83,6 +108,9 @@ ORDER BY due_at ASC;
 -- name: GetTaskByID :one
 SELECT * FROM tasks WHERE id = $1;
 
+-- name: GetTaskByIDForUpdate :one
+SELECT * FROM tasks WHERE id = $1 FOR UPDATE;
+
 -- name: CreateTask :one
 INSERT INTO tasks (
 	id,
@@ -100,7 +128,10 @@ INSERT INTO tasks (
 	last_modified_at,
 	priority,
 	due_at,
-	show_before_due_time
+	show_before_due_time,
+	sort_position,
+	estimate_minutes,
+	continuation_of
 ) VALUES (
 	$1,
 	$2,
@@ -117,7 +148,10 @@ INSERT INTO tasks (
 	$13,
 	$14,
 	$15,
-	$16
+	$16,
+	COALESCE((SELECT MAX(sort_position) FROM tasks WHERE user_id = $12 AND is_completed = FALSE), 0) + 1024,
+	$17,
+	$18
 ) RETURNING *;
 
 -- name: ToggleTask :one
@@ -152,10 +186,268 @@ SET
 	last_modified_at = $6,
 	priority = $7,
 	due_at = $8,
-	show_before_due_time = $9
+	show_before_due_time = $9,
+	estimate_minutes = $10,
+	estimate_warned_at = CASE WHEN estimate_minutes IS DISTINCT FROM $10 THEN NULL ELSE estimate_warned_at END,
+	estimate_exceeded_at = CASE WHEN estimate_minutes IS DISTINCT FROM $10 THEN NULL ELSE estimate_exceeded_at END
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteTask :exec
 DELETE FROM tasks
 WHERE id = $1;
+
+-- name: MergeTask :one
+UPDATE tasks
+SET
+	created_at = $2,
+	duration = $3,
+	is_active = $4,
+	toggled_at = $5,
+	last_modified_at = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: SetTaskSortPosition :one
+UPDATE tasks
+SET
+	sort_position = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: RenumberTaskSortPositions :exec
+UPDATE tasks
+SET sort_position = ranked.position
+FROM (
+	SELECT id, ROW_NUMBER() OVER (ORDER BY sort_position ASC, created_at ASC) * 1024 AS position
+	FROM tasks
+	WHERE user_id = $1 AND is_completed = FALSE
+) ranked
+WHERE tasks.id = ranked.id;
+
+-- name: GetRunningTasksWithEstimate :many
+SELECT * FROM tasks
+WHERE is_active = TRUE
+  AND is_completed = FALSE
+  AND estimate_minutes IS NOT NULL
+  AND estimate_exceeded_at IS NULL;
+
+-- name: MarkTaskEstimateWarned :one
+UPDATE tasks
+SET
+	estimate_warned_at = NOW(),
+	last_modified_at = $2
+WHERE id = $1 AND estimate_warned_at IS NULL
+RETURNING *;
+
+-- name: MarkTaskEstimateExceeded :one
+UPDATE tasks
+SET
+	estimate_warned_at = COALESCE(estimate_warned_at, NOW()),
+	estimate_exceeded_at = NOW(),
+	last_modified_at = $2
+WHERE id = $1 AND estimate_exceeded_at IS NULL
+RETURNING *;
+
+-- name: SetTaskEstimateAlerts :exec
+UPDATE tasks
+SET
+	estimate_warned_at = $2,
+	estimate_exceeded_at = $3
+WHERE id = $1;
+
+-- name: ResetTaskDay :one
+UPDATE tasks
+SET
+	duration = '00:00:00',
+	toggled_at = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: IncrementTaskFocusSessions :one
+UPDATE tasks
+SET
+	focus_sessions_completed = focus_sessions_completed + 1,
+	last_modified_at = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: HasActiveTask :one
+SELECT EXISTS (
+	SELECT 1 FROM tasks
+	WHERE user_id = $1 AND is_active = TRUE AND is_completed = FALSE
+) AS exists;
+
+-- name: SetTaskHiddenUntil :one
+UPDATE tasks
+SET
+	hidden_until = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ReleaseExpiredDeferrals :many
+UPDATE tasks
+SET
+	hidden_until = NULL,
+	last_modified_at = $1
+WHERE hidden_until <= NOW() AND is_completed = FALSE
+RETURNING *;
+
+-- name: MarkTasksOverdue :many
+UPDATE tasks
+SET
+	overdue_since = due_at,
+	overdue_notified_count = 0,
+	last_modified_at = $1
+WHERE overdue_since IS NULL
+	AND is_completed = FALSE
+	AND due_at IS NOT NULL
+	AND due_at <= NOW()
+RETURNING *;
+
+-- name: ClearStaleOverdue :many
+UPDATE tasks
+SET
+	overdue_since = NULL,
+	overdue_notified_count = 0,
+	last_modified_at = $1
+WHERE overdue_since IS NOT NULL
+	AND is_completed = FALSE
+	AND (due_at IS NULL OR due_at > NOW() OR due_at <> overdue_since)
+RETURNING *;
+
+-- name: GetOverdueTasksByUser :many
+SELECT *
+FROM tasks
+WHERE user_id = $1 AND is_completed = FALSE AND overdue_since IS NOT NULL
+ORDER BY overdue_since ASC;
+
+-- name: CountOverdueTasksByUser :one
+SELECT COUNT(*)
+FROM tasks
+WHERE user_id = $1 AND is_completed = FALSE AND overdue_since IS NOT NULL
+	AND (hidden_until IS NULL OR hidden_until <= NOW());
+
+-- name: SetTaskOverdueNotifiedCount :exec
+UPDATE tasks
+SET overdue_notified_count = $2
+WHERE id = $1;
+
+-- name: SetTaskOverdueState :exec
+UPDATE tasks
+SET
+	overdue_since = $2,
+	overdue_notified_count = $3
+WHERE id = $1;
+
+-- name: SetTaskState :one
+UPDATE tasks
+SET
+	state_id = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ClearTasksState :many
+UPDATE tasks
+SET
+	state_id = NULL,
+	last_modified_at = $2
+WHERE state_id = $1
+RETURNING *;
+
+-- name: SetTaskProject :one
+UPDATE tasks
+SET
+	project_id = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ClearTasksProject :many
+UPDATE tasks
+SET
+	project_id = NULL,
+	last_modified_at = $2
+WHERE project_id = $1
+RETURNING *;
+
+-- name: SetTaskBudget :one
+-- A new budget starts its alerts over
+UPDATE tasks
+SET
+	budget_minutes = $2,
+	budget_policy = $3,
+	budget_warned_at = CASE WHEN budget_minutes IS DISTINCT FROM $2 THEN NULL ELSE budget_warned_at END,
+	budget_exhausted_at = CASE WHEN budget_minutes IS DISTINCT FROM $2 THEN NULL ELSE budget_exhausted_at END,
+	last_modified_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: CopyTaskBudget :exec
+-- Rollover clones carry the budget and its alert state, their time keeps counting against it
+UPDATE tasks t
+SET
+	budget_minutes = s.budget_minutes,
+	budget_policy = s.budget_policy,
+	budget_warned_at = s.budget_warned_at,
+	budget_exhausted_at = s.budget_exhausted_at
+FROM tasks s
+WHERE t.id = @id AND s.id = @source_id;
+
+-- name: GetRunningBudgetedTasks :many
+SELECT * FROM tasks
+WHERE is_active = TRUE
+	AND is_completed = FALSE
+	AND (
+		budget_minutes IS NOT NULL
+		OR project_id IN (SELECT id FROM projects WHERE budget_minutes IS NOT NULL)
+	);
+
+-- name: GetTaskEarlierTrackedSeconds :one
+-- Time tracked before the task's current day: its own segments plus every task
+-- it continues from through clone rollovers, with their segments
+WITH RECURSIVE chain AS (
+	SELECT t.id, t.continuation_of FROM tasks t WHERE t.id = @task_id
+	UNION ALL
+	SELECT p.id, p.continuation_of FROM tasks p JOIN chain c ON p.id = c.continuation_of
+)
+SELECT (
+	COALESCE((
+		SELECT SUM(EXTRACT(EPOCH FROM p.duration::interval))
+		FROM tasks p
+		WHERE p.id IN (SELECT id FROM chain) AND p.id <> @task_id
+	), 0)
+	+ COALESCE((
+		SELECT SUM(EXTRACT(EPOCH FROM s.duration::interval))
+		FROM task_segments s
+		WHERE s.task_id IN (SELECT id FROM chain)
+	), 0)
+)::bigint AS tracked_seconds;
+
+-- name: MarkTaskBudgetWarned :exec
+UPDATE tasks
+SET budget_warned_at = COALESCE(budget_warned_at, $2)
+WHERE id = $1;
+
+-- name: MarkTaskBudgetExhausted :exec
+UPDATE tasks
+SET
+	budget_warned_at = COALESCE(budget_warned_at, $2),
+	budget_exhausted_at = COALESCE(budget_exhausted_at, $2)
+WHERE id = $1;
+
+-- name: GetRunningTaskIDsByUser :many
+SELECT id FROM tasks
+WHERE user_id = $1 AND is_active = TRUE AND is_completed = FALSE;
+
+-- name: SetTaskDuration :one
+UPDATE tasks
+SET
+	duration = $2,
+	last_modified_at = $3
+WHERE id = $1
+RETURNING *;
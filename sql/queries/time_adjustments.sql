@@ -0,0 +1,33 @@
+-- name: CreateTimeAdjustment :one
+INSERT INTO time_adjustments (id, task_id, user_id, kind, seconds, started_at, ended_at, reason, reverts_id, actor_sid)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetTimeAdjustmentByID :one
+SELECT * FROM time_adjustments
+WHERE id = $1;
+
+-- name: GetTimeAdjustmentsByTask :many
+SELECT * FROM time_adjustments
+WHERE task_id = $1
+ORDER BY created_at ASC;
+
+-- name: MarkTimeAdjustmentReverted :one
+UPDATE time_adjustments
+SET reverted_at = NOW()
+WHERE id = $1 AND reverted_at IS NULL
+RETURNING *;
+
+-- name: MoveTimeAdjustments :exec
+UPDATE time_adjustments
+SET task_id = @new_task_id
+WHERE task_id = @old_task_id;
+
+-- name: GetIntervalAdjustmentsInRange :many
+SELECT * FROM time_adjustments
+WHERE user_id = @user_id
+	AND kind = 'interval'
+	AND reverted_at IS NULL
+	AND ended_at > @start_at
+	AND started_at < @end_at
+ORDER BY started_at ASC;
@@ -17,6 +17,9 @@ RETURNING *;
 -- name: GetUserByEmail :one
 SELECT * FROM users WHERE email = $1;
 
+-- name: GetUserByID :one
+SELECT * FROM users WHERE id = $1;
+
 -- name: GetUserByGoogleUID :one
 SELECT * FROM users WHERE google_uid = $1;
 
@@ -40,3 +43,98 @@ SET
 WHERE
 	id = $1
 RETURNING *;
+
+-- name: GetUsersDueForRollover :many
+SELECT * FROM users
+WHERE last_rollover_at < (
+	date_trunc('day', (NOW() AT TIME ZONE timezone) - make_interval(hours => rollover_hour) + INTERVAL '1 minute')
+	+ make_interval(hours => rollover_hour)
+	- INTERVAL '1 minute'
+) AT TIME ZONE timezone;
+
+-- name: MarkUserRolledOver :exec
+UPDATE users
+SET last_rollover_at = $2
+WHERE id = $1;
+
+-- name: UpdateUserTimeSettings :one
+UPDATE users
+SET
+	timezone = $2,
+	rollover_hour = $3,
+	rollover_mode = $4,
+	last_rollover_at = NOW(),
+	updated_at = NOW()
+WHERE
+	id = $1
+RETURNING *;
+
+-- name: TouchUserActivity :exec
+UPDATE users
+SET last_activity_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateUserIdleThreshold :exec
+UPDATE users
+SET
+	idle_threshold_minutes = $2,
+	updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateUserWorkingHours :exec
+UPDATE users
+SET
+	work_start_minutes = $2,
+	work_end_minutes = $3,
+	work_days = $4,
+	nudge_after_minutes = $5,
+	nudge_interval_minutes = $6,
+	updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetUsersWithWorkingHours :many
+SELECT * FROM users
+WHERE work_start_minutes IS NOT NULL AND work_end_minutes IS NOT NULL;
+
+-- name: SetUserNothingRunningSince :exec
+UPDATE users
+SET nothing_running_since = $2
+WHERE id = $1;
+
+-- name: MarkUserNudged :exec
+UPDATE users
+SET last_nudged_at = NOW()
+WHERE id = $1;
+
+-- name: GetUsersWithPendingOverdueEscalations :many
+SELECT * FROM users u
+WHERE EXISTS (
+	SELECT 1 FROM tasks t
+	WHERE t.user_id = u.id
+		AND t.is_completed = FALSE
+		AND t.overdue_since IS NOT NULL
+		AND t.overdue_notified_count < cardinality(u.overdue_escalation_minutes)
+);
+
+-- name: UpdateUserOverdueEscalation :exec
+UPDATE users
+SET overdue_escalation_minutes = $2,
+	updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateUserDayGapsNotify :exec
+UPDATE users
+SET day_gaps_notify = $2,
+	updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetUsersWithDayGapsNotify :many
+SELECT * FROM users
+WHERE day_gaps_notify = TRUE
+	AND work_start_minutes IS NOT NULL
+	AND work_end_minutes IS NOT NULL;
+
+-- name: SetUserDayGapsNotifiedOn :exec
+UPDATE users
+SET day_gaps_notified_on = $2
+WHERE id = $1;
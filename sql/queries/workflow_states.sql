@@ -0,0 +1,27 @@
+-- name: GetWorkflowStatesByUser :many
+SELECT * FROM workflow_states
+WHERE user_id = $1
+ORDER BY position ASC;
+
+-- name: GetWorkflowStateByID :one
+SELECT * FROM workflow_states
+WHERE id = $1;
+
+-- name: CreateWorkflowState :one
+INSERT INTO workflow_states (id, user_id, name, kind, position)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateWorkflowState :one
+UPDATE workflow_states
+SET
+	name = $2,
+	kind = $3,
+	position = $4,
+	updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWorkflowState :exec
+DELETE FROM workflow_states
+WHERE id = $1;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- task_id is intentionally not a foreign key so history survives deletes, splits and merges.
+CREATE TABLE task_revisions (
+	id UUID PRIMARY KEY,
+	task_id UUID NOT NULL,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	actor_sid UUID,
+	action TEXT NOT NULL,
+	changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+	snapshot JSONB NOT NULL DEFAULT '{}'::jsonb,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_revisions_task_id ON task_revisions(task_id, created_at);
+CREATE INDEX idx_task_revisions_user_id ON task_revisions(user_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_revisions_user_id;
+DROP INDEX IF EXISTS idx_task_revisions_task_id;
+DROP TABLE IF EXISTS task_revisions;
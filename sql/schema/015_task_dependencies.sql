@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE task_dependencies (
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	depends_on_task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (task_id, depends_on_task_id),
+	CHECK (task_id <> depends_on_task_id)
+);
+
+CREATE INDEX idx_task_dependencies_depends_on ON task_dependencies(depends_on_task_id);
+CREATE INDEX idx_task_dependencies_user_id ON task_dependencies(user_id);
+
+-- Maintained by the server whenever a dependency or a blocker's completion changes.
+ALTER TABLE tasks ADD COLUMN blocked BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'system', 'achievement', 'other'));
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS blocked;
+
+DROP INDEX IF EXISTS idx_task_dependencies_user_id;
+DROP INDEX IF EXISTS idx_task_dependencies_depends_on;
+DROP TABLE IF EXISTS task_dependencies;
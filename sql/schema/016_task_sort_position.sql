@@ -0,0 +1,17 @@
+-- +goose Up
+-- Fractional rank within a user's active list; a move only rewrites the moved row.
+ALTER TABLE tasks ADD COLUMN sort_position DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE tasks
+SET sort_position = ranked.position
+FROM (
+	SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at ASC) * 1024 AS position
+	FROM tasks
+) ranked
+WHERE tasks.id = ranked.id;
+
+CREATE INDEX idx_tasks_user_id_sort_position ON tasks(user_id, sort_position) WHERE is_completed = FALSE;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_user_id_sort_position;
+ALTER TABLE tasks DROP COLUMN IF EXISTS sort_position;
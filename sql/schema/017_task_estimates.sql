@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN estimate_minutes INTEGER;
+ALTER TABLE tasks ADD COLUMN estimate_warned_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN estimate_exceeded_at TIMESTAMPTZ;
+
+ALTER TABLE schedules ADD COLUMN estimate_minutes INTEGER;
+
+CREATE INDEX idx_tasks_running_estimate ON tasks(id)
+	WHERE is_active = TRUE AND is_completed = FALSE AND estimate_minutes IS NOT NULL AND estimate_exceeded_at IS NULL;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'system', 'achievement', 'other'));
+
+DROP INDEX IF EXISTS idx_tasks_running_estimate;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS estimate_minutes;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_exceeded_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_warned_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_minutes;
@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT 'Europe/Bucharest';
+ALTER TABLE users ADD COLUMN rollover_hour INTEGER NOT NULL DEFAULT 0 CHECK (rollover_hour BETWEEN 0 AND 23);
+-- Starts at NOW() so existing users are not rolled over on the first tick after deploy.
+ALTER TABLE users ADD COLUMN last_rollover_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS last_rollover_at;
+ALTER TABLE users DROP COLUMN IF EXISTS rollover_hour;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- 'clone' completes open tasks at rollover and re-creates them; 'continue' keeps the task
+-- and closes the day's work out as a task_segments row.
+ALTER TABLE users ADD COLUMN rollover_mode TEXT NOT NULL DEFAULT 'clone' CHECK (rollover_mode IN ('clone', 'continue'));
+
+-- Set on tasks re-created by a 'clone' rollover, pointing at the task they carry on.
+ALTER TABLE tasks ADD COLUMN continuation_of UUID REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE TABLE task_segments (
+	id UUID PRIMARY KEY,
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	day_start TIMESTAMPTZ NOT NULL,
+	ended_at TIMESTAMPTZ NOT NULL,
+	duration TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_segments_task_id ON task_segments(task_id);
+CREATE INDEX idx_task_segments_user_id_ended_at ON task_segments(user_id, ended_at);
+CREATE INDEX idx_tasks_continuation_of ON tasks(continuation_of) WHERE continuation_of IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_continuation_of;
+DROP INDEX IF EXISTS idx_task_segments_user_id_ended_at;
+DROP INDEX IF EXISTS idx_task_segments_task_id;
+DROP TABLE IF EXISTS task_segments;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS continuation_of;
+ALTER TABLE users DROP COLUMN IF EXISTS rollover_mode;
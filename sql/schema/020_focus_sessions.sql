@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE focus_sessions (
+	id UUID PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	phase TEXT NOT NULL DEFAULT 'work' CHECK (phase IN ('work', 'break')),
+	work_minutes INTEGER NOT NULL CHECK (work_minutes > 0),
+	break_minutes INTEGER NOT NULL CHECK (break_minutes > 0),
+	cycles_planned INTEGER NOT NULL CHECK (cycles_planned > 0),
+	cycles_completed INTEGER NOT NULL DEFAULT 0,
+	phase_ends_at TIMESTAMPTZ NOT NULL,
+	status TEXT NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'cancelled')),
+	started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	ended_at TIMESTAMPTZ
+);
+
+-- A user runs at most one focus session at a time
+CREATE UNIQUE INDEX idx_focus_sessions_running_user ON focus_sessions(user_id) WHERE status = 'running';
+CREATE INDEX idx_focus_sessions_running_phase_ends_at ON focus_sessions(phase_ends_at) WHERE status = 'running';
+CREATE INDEX idx_focus_sessions_task_id ON focus_sessions(task_id);
+
+ALTER TABLE tasks ADD COLUMN focus_sessions_completed INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'focus', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'system', 'achievement', 'other'));
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS focus_sessions_completed;
+
+DROP INDEX IF EXISTS idx_focus_sessions_task_id;
+DROP INDEX IF EXISTS idx_focus_sessions_running_phase_ends_at;
+DROP INDEX IF EXISTS idx_focus_sessions_running_user;
+DROP TABLE IF EXISTS focus_sessions;
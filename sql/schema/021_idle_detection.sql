@@ -0,0 +1,35 @@
+-- +goose Up
+-- Set by client activity heartbeats; NULL means the user's clients never reported activity,
+-- so idle detection stays off for them.
+ALTER TABLE users ADD COLUMN last_activity_at TIMESTAMPTZ;
+-- 0 disables idle detection
+ALTER TABLE users ADD COLUMN idle_threshold_minutes INTEGER NOT NULL DEFAULT 15 CHECK (idle_threshold_minutes >= 0);
+
+-- Time cut from a task when it was paused for inactivity, until the user keeps or discards it
+CREATE TABLE idle_periods (
+	id UUID PRIMARY KEY,
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	idle_from TIMESTAMPTZ NOT NULL,
+	idle_until TIMESTAMPTZ NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'kept', 'discarded')),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_idle_periods_user_id ON idle_periods(user_id, created_at);
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'focus', 'system', 'achievement', 'other'));
+
+DROP INDEX IF EXISTS idx_idle_periods_user_id;
+DROP TABLE IF EXISTS idle_periods;
+
+ALTER TABLE users DROP COLUMN IF EXISTS idle_threshold_minutes;
+ALTER TABLE users DROP COLUMN IF EXISTS last_activity_at;
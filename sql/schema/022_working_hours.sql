@@ -0,0 +1,21 @@
+-- +goose Up
+-- Working hours are minutes from local midnight in the user's timezone; NULL disables nudges.
+-- An end before the start spans midnight. work_days are ISO weekdays (1 = Monday).
+ALTER TABLE users ADD COLUMN work_start_minutes INTEGER CHECK (work_start_minutes >= 0 AND work_start_minutes < 1440);
+ALTER TABLE users ADD COLUMN work_end_minutes INTEGER CHECK (work_end_minutes >= 0 AND work_end_minutes < 1440);
+ALTER TABLE users ADD COLUMN work_days INTEGER[] NOT NULL DEFAULT '{1,2,3,4,5}';
+ALTER TABLE users ADD COLUMN nudge_after_minutes INTEGER NOT NULL DEFAULT 15 CHECK (nudge_after_minutes > 0);
+ALTER TABLE users ADD COLUMN nudge_interval_minutes INTEGER NOT NULL DEFAULT 30 CHECK (nudge_interval_minutes > 0);
+
+-- Nudge bookkeeping, maintained by the server
+ALTER TABLE users ADD COLUMN nothing_running_since TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN last_nudged_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS last_nudged_at;
+ALTER TABLE users DROP COLUMN IF EXISTS nothing_running_since;
+ALTER TABLE users DROP COLUMN IF EXISTS nudge_interval_minutes;
+ALTER TABLE users DROP COLUMN IF EXISTS nudge_after_minutes;
+ALTER TABLE users DROP COLUMN IF EXISTS work_days;
+ALTER TABLE users DROP COLUMN IF EXISTS work_end_minutes;
+ALTER TABLE users DROP COLUMN IF EXISTS work_start_minutes;
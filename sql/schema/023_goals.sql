@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE goals (
+	id UUID PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	scope TEXT NOT NULL CHECK (scope IN ('category', 'tag')),
+	scope_value TEXT NOT NULL,
+	period TEXT NOT NULL CHECK (period IN ('day', 'week', 'month')),
+	target_minutes INTEGER NOT NULL CHECK (target_minutes > 0),
+	direction TEXT NOT NULL CHECK (direction IN ('at_least', 'at_most')),
+	-- Start of the last period the goal was met in, so the achievement fires once per period
+	last_achieved_period_start TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_goals_user_id ON goals(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_goals_user_id;
+DROP TABLE IF EXISTS goals;
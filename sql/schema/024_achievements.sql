@@ -0,0 +1,20 @@
+-- +goose Up
+-- code names the rule that produced the achievement, key makes it unique per occasion
+-- (a streak, a day, a schedule week, a goal period...).
+CREATE TABLE achievements (
+	id UUID PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	code TEXT NOT NULL,
+	key TEXT NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+	earned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	UNIQUE (user_id, code, key)
+);
+
+CREATE INDEX idx_achievements_user_id_earned_at ON achievements(user_id, earned_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_achievements_user_id_earned_at;
+DROP TABLE IF EXISTS achievements;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE task_checklist_items (
+	id UUID PRIMARY KEY,
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	text TEXT NOT NULL,
+	checked BOOLEAN NOT NULL DEFAULT FALSE,
+	checked_at TIMESTAMPTZ,
+	position DOUBLE PRECISION NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items(task_id, position);
+
+-- Checklist progress on the task itself, maintained by the server like blocked
+ALTER TABLE tasks ADD COLUMN checklist_total INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN checklist_checked INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE tasks DROP COLUMN IF EXISTS checklist_checked;
+ALTER TABLE tasks DROP COLUMN IF EXISTS checklist_total;
+
+DROP INDEX IF EXISTS idx_task_checklist_items_task_id;
+DROP TABLE IF EXISTS task_checklist_items;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE task_notes (
+	id UUID PRIMARY KEY,
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	body TEXT NOT NULL,
+	-- session that wrote the note and the label its device gave itself
+	device_sid UUID,
+	device TEXT,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	edited_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_task_notes_task_id ON task_notes(task_id, created_at);
+CREATE INDEX idx_task_notes_user_id ON task_notes(user_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_notes_user_id;
+DROP INDEX IF EXISTS idx_task_notes_task_id;
+DROP TABLE IF EXISTS task_notes;
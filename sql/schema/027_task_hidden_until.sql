@@ -0,0 +1,9 @@
+-- +goose Up
+-- Deferred tasks stay out of the active list until hidden_until passes
+ALTER TABLE tasks ADD COLUMN hidden_until TIMESTAMPTZ;
+
+CREATE INDEX idx_tasks_hidden_until ON tasks(hidden_until) WHERE hidden_until IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_hidden_until;
+ALTER TABLE tasks DROP COLUMN IF EXISTS hidden_until;
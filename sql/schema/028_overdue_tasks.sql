@@ -0,0 +1,37 @@
+-- +goose Up
+-- overdue_since is set by the server once due_at passes; overdue_notified_count is how
+-- many of the owner's escalation steps were sent for the current due date
+ALTER TABLE tasks ADD COLUMN overdue_since TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN overdue_notified_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_tasks_overdue_since ON tasks(user_id, overdue_since) WHERE overdue_since IS NOT NULL;
+
+-- Minutes after the due time at which an overdue notification is sent
+ALTER TABLE users ADD COLUMN overdue_escalation_minutes INTEGER[] NOT NULL DEFAULT '{0,60,1440}';
+
+-- Tasks that were already overdue count as fully escalated, so the first tick after
+-- deploying does not send an urgent notification for each of them at once
+UPDATE tasks t
+SET
+	overdue_since = t.due_at,
+	overdue_notified_count = cardinality(u.overdue_escalation_minutes)
+FROM users u
+WHERE u.id = t.user_id
+	AND t.is_completed = FALSE
+	AND t.due_at IS NOT NULL
+	AND t.due_at <= NOW();
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'overdue', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+ALTER TABLE users DROP COLUMN IF EXISTS overdue_escalation_minutes;
+
+DROP INDEX IF EXISTS idx_tasks_overdue_since;
+ALTER TABLE tasks DROP COLUMN IF EXISTS overdue_notified_count;
+ALTER TABLE tasks DROP COLUMN IF EXISTS overdue_since;
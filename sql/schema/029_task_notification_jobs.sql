@@ -0,0 +1,18 @@
+-- +goose Up
+-- Due reminders for tasks created or edited directly hang off the task instead of an occurrence
+ALTER TABLE notification_jobs ADD COLUMN task_id uuid REFERENCES tasks(id) ON DELETE CASCADE;
+ALTER TABLE notification_jobs ALTER COLUMN occurrence_id DROP NOT NULL;
+ALTER TABLE notification_jobs ADD CONSTRAINT notification_jobs_target_check
+	CHECK (occurrence_id IS NOT NULL OR task_id IS NOT NULL);
+
+-- One pending job per offset; sent and canceled jobs stay as history
+CREATE UNIQUE INDEX idx_jobs_task_offset
+	ON notification_jobs (task_id, offset_minutes)
+	WHERE task_id IS NOT NULL AND sent_at IS NULL AND canceled_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_jobs_task_offset;
+DELETE FROM notification_jobs WHERE occurrence_id IS NULL;
+ALTER TABLE notification_jobs DROP CONSTRAINT IF EXISTS notification_jobs_target_check;
+ALTER TABLE notification_jobs ALTER COLUMN occurrence_id SET NOT NULL;
+ALTER TABLE notification_jobs DROP COLUMN IF EXISTS task_id;
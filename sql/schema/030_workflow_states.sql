@@ -0,0 +1,24 @@
+-- +goose Up
+-- User-defined workflow columns; kind decides what moving a task into the column does
+CREATE TABLE workflow_states (
+	id UUID PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	kind TEXT NOT NULL DEFAULT 'todo' CHECK (kind IN ('todo', 'doing', 'done')),
+	position INTEGER NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_workflow_states_user_id ON workflow_states(user_id, position);
+
+ALTER TABLE tasks ADD COLUMN state_id UUID REFERENCES workflow_states(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_state_id ON tasks(state_id) WHERE state_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_state_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS state_id;
+
+DROP INDEX IF EXISTS idx_workflow_states_user_id;
+DROP TABLE IF EXISTS workflow_states;
@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE projects (
+	id UUID PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	category TEXT,
+	deadline TIMESTAMPTZ,
+	status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'on_hold', 'completed', 'archived')),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_projects_user_id ON projects(user_id, status);
+
+ALTER TABLE tasks ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_project_id ON tasks(project_id) WHERE project_id IS NOT NULL;
+
+-- Deadline reminders hang off the project like due reminders hang off a task
+ALTER TABLE notification_jobs ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE CASCADE;
+ALTER TABLE notification_jobs DROP CONSTRAINT IF EXISTS notification_jobs_target_check;
+ALTER TABLE notification_jobs ADD CONSTRAINT notification_jobs_target_check
+	CHECK (occurrence_id IS NOT NULL OR task_id IS NOT NULL OR project_id IS NOT NULL);
+
+CREATE UNIQUE INDEX idx_jobs_project_offset
+	ON notification_jobs (project_id, offset_minutes)
+	WHERE project_id IS NOT NULL AND sent_at IS NULL AND canceled_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_jobs_project_offset;
+DELETE FROM notification_jobs WHERE project_id IS NOT NULL;
+ALTER TABLE notification_jobs DROP CONSTRAINT IF EXISTS notification_jobs_target_check;
+ALTER TABLE notification_jobs ADD CONSTRAINT notification_jobs_target_check
+	CHECK (occurrence_id IS NOT NULL OR task_id IS NOT NULL);
+ALTER TABLE notification_jobs DROP COLUMN IF EXISTS project_id;
+
+DROP INDEX IF EXISTS idx_tasks_project_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+
+DROP INDEX IF EXISTS idx_projects_user_id;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Hard time budgets; the stamps record which alerts went out for the current budget
+ALTER TABLE tasks ADD COLUMN budget_minutes INTEGER CHECK (budget_minutes > 0);
+ALTER TABLE tasks ADD COLUMN budget_policy TEXT NOT NULL DEFAULT 'continue' CHECK (budget_policy IN ('continue', 'pause'));
+ALTER TABLE tasks ADD COLUMN budget_warned_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN budget_exhausted_at TIMESTAMPTZ;
+
+ALTER TABLE projects ADD COLUMN budget_minutes INTEGER CHECK (budget_minutes > 0);
+ALTER TABLE projects ADD COLUMN budget_warned_at TIMESTAMPTZ;
+ALTER TABLE projects ADD COLUMN budget_exhausted_at TIMESTAMPTZ;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'overdue', 'budget', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'overdue', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_exhausted_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_warned_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_minutes;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS budget_exhausted_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS budget_warned_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS budget_policy;
+ALTER TABLE tasks DROP COLUMN IF EXISTS budget_minutes;
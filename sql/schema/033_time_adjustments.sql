@@ -0,0 +1,30 @@
+-- +goose Up
+-- Manual corrections to a task's tracked time. Each one is applied to the task's
+-- duration and kept here, signed, so reports can tell it apart from timed work.
+-- A revert is recorded as its own counter-adjustment pointing at the original.
+CREATE TABLE time_adjustments (
+	id UUID PRIMARY KEY,
+	task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	kind TEXT NOT NULL CHECK (kind IN ('add', 'subtract', 'interval', 'revert')),
+	seconds BIGINT NOT NULL,
+	started_at TIMESTAMPTZ,
+	ended_at TIMESTAMPTZ,
+	reason TEXT NOT NULL CHECK (btrim(reason) <> ''),
+	reverts_id UUID REFERENCES time_adjustments(id) ON DELETE CASCADE,
+	actor_sid UUID,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	reverted_at TIMESTAMPTZ,
+	CONSTRAINT time_adjustments_interval_check
+		CHECK (kind <> 'interval' OR (started_at IS NOT NULL AND ended_at > started_at)),
+	CONSTRAINT time_adjustments_revert_check
+		CHECK ((kind = 'revert') = (reverts_id IS NOT NULL))
+);
+
+CREATE INDEX idx_time_adjustments_task_id ON time_adjustments(task_id, created_at);
+CREATE INDEX idx_time_adjustments_user_id ON time_adjustments(user_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_time_adjustments_user_id;
+DROP INDEX IF EXISTS idx_time_adjustments_task_id;
+DROP TABLE IF EXISTS time_adjustments;
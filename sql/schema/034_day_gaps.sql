@@ -0,0 +1,17 @@
+-- +goose Up
+-- Opt-in summary of the untracked gaps in the working window, sent once it ends
+ALTER TABLE users ADD COLUMN day_gaps_notify BOOLEAN NOT NULL DEFAULT FALSE;
+-- The working day (in the user's timezone) the last summary was sent for
+ALTER TABLE users ADD COLUMN day_gaps_notified_on DATE;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'overdue', 'budget', 'day_gaps', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+-- +goose Down
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_notification_type_check
+	CHECK (notification_type IN ('reminder', 'due_task', 'task_completed', 'task_created', 'task_updated', 'task_unblocked', 'overrun', 'overdue', 'budget', 'focus', 'idle', 'system', 'achievement', 'other'));
+
+ALTER TABLE users DROP COLUMN IF EXISTS day_gaps_notified_on;
+ALTER TABLE users DROP COLUMN IF EXISTS day_gaps_notify;
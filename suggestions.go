@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+// Weights of the ranking factors, in points
+const (
+	suggestionPriorityWeight = 3.0  // per priority step above 10, so priority 1 scores 30
+	suggestionOverdueBase    = 50.0 // any overdue task
+	suggestionOverdueMax     = 24.0 // extra, half a point per hour overdue
+	suggestionDueWeight      = 40.0 // due right now, fading to 0 a week out
+	suggestionFitsBonus      = 10.0
+	suggestionTooLongPenalty = -15.0
+	suggestionGoalWeight     = 25.0 // at_least goal with nothing tracked yet
+	suggestionLimitPenalty   = -20.0
+
+	suggestionDueHorizon = 7 * 24 * time.Hour
+
+	defaultSuggestionLimit = 3
+	maxSuggestionLimit     = 20
+)
+
+type suggestionReason struct {
+	Factor string  `json:"factor"`
+	Points float64 `json:"points"`
+	Detail string  `json:"detail"`
+}
+
+type taskSuggestion struct {
+	Task    database.Task      `json:"task"`
+	Score   float64            `json:"score"`
+	Reasons []suggestionReason `json:"reasons"`
+}
+
+// rankTasks scores every open task that can be worked on and returns them best first.
+// Lower priority numbers are more important, priority 1 is the top. earlier holds the
+// time tasks tracked before their current day, see GetTaskEarlierTrackedSeconds.
+func rankTasks(user database.User, tasks []database.Task, earlier map[uuid.UUID]int64, goals []goalProgress, now time.Time) []taskSuggestion {
+	minutesLeft, working := userWorkMinutesLeft(user, now)
+
+	suggestions := make([]taskSuggestion, 0, len(tasks))
+	for _, task := range tasks {
+		if task.IsCompleted || task.Blocked {
+			continue
+		}
+
+		suggestion := taskSuggestion{Task: task, Reasons: []suggestionReason{}}
+		add := func(factor string, points float64, detail string) {
+			points = math.Round(points*10) / 10
+			if points == 0 {
+				return
+			}
+			suggestion.Score += points
+			suggestion.Reasons = append(suggestion.Reasons, suggestionReason{Factor: factor, Points: points, Detail: detail})
+		}
+
+		if task.Priority.Valid && task.Priority.Int32 < 11 {
+			add("priority", float64(11-task.Priority.Int32)*suggestionPriorityWeight,
+				fmt.Sprintf("Priority %d", task.Priority.Int32))
+		}
+
+		if task.DueAt.Valid {
+			untilDue := task.DueAt.Time.Sub(now)
+			switch {
+			case untilDue < 0:
+				hours := -untilDue.Hours()
+				add("overdue", suggestionOverdueBase+math.Min(hours/2, suggestionOverdueMax),
+					fmt.Sprintf("Overdue by %s", formatSuggestionDuration(-untilDue)))
+			case untilDue < suggestionDueHorizon:
+				add("due_soon", suggestionDueWeight*(1-float64(untilDue)/float64(suggestionDueHorizon)),
+					fmt.Sprintf("Due in %s", formatSuggestionDuration(untilDue)))
+			}
+		}
+
+		if working && task.EstimateMinutes.Valid {
+			tracked, err := taskTrackedSeconds(task, now.UnixMilli())
+			if err == nil {
+				remaining := int64(task.EstimateMinutes.Int32) - (earlier[task.ID]+tracked)/60
+				if remaining <= minutesLeft {
+					add("fits_workday", suggestionFitsBonus,
+						fmt.Sprintf("About %d minutes left of the estimate, %d minutes left in the working day", max(remaining, 0), minutesLeft))
+				} else {
+					add("fits_workday", suggestionTooLongPenalty,
+						fmt.Sprintf("Needs about %d minutes, only %d minutes left in the working day", remaining, minutesLeft))
+				}
+			}
+		}
+
+		for _, progress := range goals {
+			if !goalMatchesTask(progress.Goal, task) {
+				continue
+			}
+			if progress.Goal.Direction == "at_least" && !progress.Met {
+				missing := progress.TargetSeconds - progress.TrackedSeconds
+				add("goal", suggestionGoalWeight*float64(missing)/float64(progress.TargetSeconds),
+					fmt.Sprintf("%d minutes short of the %s goal for %s", missing/60, progress.Goal.Period, progress.Goal.ScopeValue))
+			}
+			if progress.Goal.Direction == "at_most" && progress.TrackedSeconds >= progress.TargetSeconds {
+				add("goal", suggestionLimitPenalty,
+					fmt.Sprintf("The %s limit for %s is used up", progress.Goal.Period, progress.Goal.ScopeValue))
+			}
+		}
+
+		suggestion.Score = math.Round(suggestion.Score*10) / 10
+		suggestions = append(suggestions, suggestion)
+	}
+
+	// Ties keep the user's own ordering
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Task.SortPosition < suggestions[j].Task.SortPosition
+	})
+	return suggestions
+}
+
+func goalMatchesTask(goal database.Goal, task database.Task) bool {
+	if goal.Scope == "category" {
+		return strings.EqualFold(goal.ScopeValue, task.Category)
+	}
+	for _, tag := range task.Tags {
+		if strings.EqualFold(goal.ScopeValue, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSuggestionDuration renders a duration as "3d 4h", "5h 10m" or "25m"
+func formatSuggestionDuration(d time.Duration) string {
+	minutes := int64(d.Minutes())
+	switch {
+	case minutes >= 24*60:
+		return fmt.Sprintf("%dd %dh", minutes/(24*60), minutes%(24*60)/60)
+	case minutes >= 60:
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+func (cfg *config) WSOnNextTaskSuggestions(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("next_task_suggestions").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			Limit int `json:"limit"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	limit := payload.Data.Limit
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(ctx, database.GetActiveTaskByUUIDParams{UserID: client.User.ID})
+	if err != nil {
+		logDBError("Failed to load tasks for suggestions", err)
+		return sendError(c, ErrorDatabaseError, "Failed to load tasks", 500)
+	}
+
+	// Estimates span rollovers, so count what the tasks tracked on earlier days
+	earlier := make(map[uuid.UUID]int64)
+	for _, task := range tasks {
+		if !task.EstimateMinutes.Valid {
+			continue
+		}
+		seconds, err := cfg.DB.GetTaskEarlierTrackedSeconds(ctx, task.ID)
+		if err != nil {
+			logDBError("Failed to load earlier tracked time for suggestions", err)
+			return sendError(c, ErrorDatabaseError, "Failed to load tasks", 500)
+		}
+		earlier[task.ID] = seconds
+	}
+
+	var goals []goalProgress
+	if cfg.GoalService != nil {
+		goals, err = cfg.GoalService.Progress(ctx, client.User)
+		if err != nil {
+			logDBError("Failed to load goal progress for suggestions", err)
+			return sendError(c, ErrorDatabaseError, "Failed to load goals", 500)
+		}
+	}
+
+	now := time.Now()
+	suggestions := rankTasks(client.User, tasks, earlier, goals, now)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	cfg.WSClientManager.SendToClient(ctx, "next_task_suggestions", SID, struct {
+		GeneratedAt time.Time        `json:"generated_at"`
+		Suggestions []taskSuggestion `json:"suggestions"`
+	}{
+		GeneratedAt: now,
+		Suggestions: suggestions,
+	})
+	return nil
+}
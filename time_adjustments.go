@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+const (
+	maxTimeAdjustMinutes   = 24 * 60
+	maxTimeAdjustReasonLen = 500
+)
+
+type timeAdjustT struct {
+	TaskID    uuid.UUID  `json:"task_id"`
+	Kind      string     `json:"kind"`
+	Minutes   int64      `json:"minutes"`
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Reason    string     `json:"reason"`
+}
+
+// timeAdjustSeconds validates an adjustment and returns the signed seconds it
+// moves the task's duration by, or a client facing message
+func timeAdjustSeconds(adjust timeAdjustT, now time.Time) (int64, string) {
+	reason := strings.TrimSpace(adjust.Reason)
+	switch {
+	case reason == "":
+		return 0, "reason is required"
+	case len(reason) > maxTimeAdjustReasonLen:
+		return 0, fmt.Sprintf("reason is limited to %d characters", maxTimeAdjustReasonLen)
+	}
+
+	switch adjust.Kind {
+	case "add", "subtract":
+		if adjust.Minutes <= 0 || adjust.Minutes > maxTimeAdjustMinutes {
+			return 0, fmt.Sprintf("minutes must be between 1 and %d", maxTimeAdjustMinutes)
+		}
+		if adjust.Kind == "subtract" {
+			return -adjust.Minutes * 60, ""
+		}
+		return adjust.Minutes * 60, ""
+	case "interval":
+		switch {
+		case adjust.StartedAt == nil || adjust.EndedAt == nil:
+			return 0, "started_at and ended_at are required for an interval"
+		case !adjust.EndedAt.After(*adjust.StartedAt):
+			return 0, "ended_at must be after started_at"
+		case adjust.EndedAt.After(now):
+			return 0, "An interval must be in the past"
+		case adjust.EndedAt.Sub(*adjust.StartedAt) > maxTimeAdjustMinutes*time.Minute:
+			return 0, fmt.Sprintf("An interval is limited to %d minutes", maxTimeAdjustMinutes)
+		}
+		return int64(adjust.EndedAt.Sub(*adjust.StartedAt).Seconds()), ""
+	}
+	return 0, "kind must be 'add', 'subtract' or 'interval'"
+}
+
+// adjustedDuration is the task's duration moved by seconds. A running segment is
+// not part of the duration, so it cannot be subtracted from.
+func adjustedDuration(task database.Task, seconds int64) (string, bool, error) {
+	return shiftDuration(task.Duration, seconds)
+}
+
+// shiftDuration moves a stored duration by seconds; ok is false when it would go
+// below zero
+func shiftDuration(stored string, seconds int64) (string, bool, error) {
+	durationMs, err := durationStrToInt(stored)
+	if err != nil {
+		return "", false, err
+	}
+	total := durationMs/1000 + seconds
+	if total < 0 {
+		return "", false, nil
+	}
+	duration, err := durationIntToStr(total)
+	return duration, true, err
+}
+
+// saveTimeAdjustment applies an adjustment to the task's duration and stores its
+// record in one transaction. The task is locked and re-read first, so the new
+// duration is computed from the stored one rather than a copy loaded earlier; ok is
+// false when there is less recorded time than the adjustment takes off.
+// A revert also marks the adjustment it undoes, and lands where that one did: when a
+// continue rollover has closed that day out since, the day's segment is corrected
+// rather than today's duration, the same attribution reports use.
+func (cfg *config) saveTimeAdjustment(ctx context.Context, SID uuid.UUID, taskID uuid.UUID, params database.CreateTimeAdjustmentParams) (database.Task, database.TimeAdjustment, bool, error) {
+	tx, err := cfg.DBPool.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Task{}, database.TimeAdjustment{}, false, err
+	}
+	defer tx.Rollback()
+
+	queries := cfg.DB.WithTx(tx)
+
+	task, err := queries.GetTaskByIDForUpdate(ctx, taskID)
+	if err != nil {
+		return task, database.TimeAdjustment{}, false, err
+	}
+
+	action := "time_adjust"
+	updated := task
+	onSegment := false
+	if params.RevertsID.Valid {
+		action = "time_adjust_revert"
+		original, err := queries.MarkTimeAdjustmentReverted(ctx, params.RevertsID.UUID)
+		if err != nil {
+			return task, database.TimeAdjustment{}, false, err
+		}
+
+		segment, err := queries.GetTaskSegmentEndedAfter(ctx, database.GetTaskSegmentEndedAfterParams{
+			TaskID:  task.ID,
+			EndedAt: original.CreatedAt,
+		})
+		switch {
+		case err == nil:
+			duration, ok, err := shiftDuration(segment.Duration, params.Seconds)
+			if err != nil || !ok {
+				return task, database.TimeAdjustment{}, false, err
+			}
+			_, err = queries.SetTaskSegmentDuration(ctx, database.SetTaskSegmentDurationParams{
+				ID:       segment.ID,
+				Duration: duration,
+			})
+			if err != nil {
+				return task, database.TimeAdjustment{}, false, err
+			}
+			onSegment = true
+		case err != sql.ErrNoRows:
+			return task, database.TimeAdjustment{}, false, err
+		}
+	}
+
+	if !onSegment {
+		duration, ok, err := adjustedDuration(task, params.Seconds)
+		if err != nil || !ok {
+			return task, database.TimeAdjustment{}, false, err
+		}
+
+		updated, err = queries.SetTaskDuration(ctx, database.SetTaskDurationParams{
+			ID:             task.ID,
+			Duration:       duration,
+			LastModifiedAt: time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return task, database.TimeAdjustment{}, false, err
+		}
+	}
+
+	adjustment, err := queries.CreateTimeAdjustment(ctx, params)
+	if err != nil {
+		return task, adjustment, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return task, adjustment, false, err
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, action, &task, updated)
+	return updated, adjustment, true, nil
+}
+
+// WSOnTimeAdjust adds or subtracts time on a task, or books a past interval on it
+func (cfg *config) WSOnTimeAdjust(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("time_adjust").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data timeAdjustT `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	seconds, message := timeAdjustSeconds(payload.Data, time.Now())
+	if message != "" {
+		return sendError(c, "invalid_request", message, 400)
+	}
+
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	params := database.CreateTimeAdjustmentParams{
+		ID:       uuid.New(),
+		TaskID:   task.ID,
+		UserID:   client.User.ID,
+		Kind:     payload.Data.Kind,
+		Seconds:  seconds,
+		Reason:   strings.TrimSpace(payload.Data.Reason),
+		ActorSid: uuid.NullUUID{UUID: SID, Valid: true},
+	}
+	if payload.Data.Kind == "interval" {
+		params.StartedAt = sql.NullTime{Time: payload.Data.StartedAt.UTC(), Valid: true}
+		params.EndedAt = sql.NullTime{Time: payload.Data.EndedAt.UTC(), Valid: true}
+	}
+
+	task, adjustment, ok, err := cfg.saveTimeAdjustment(ctx, SID, task.ID, params)
+	if err != nil {
+		logDBError("Failed to adjust time of task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to adjust time", 500)
+	}
+	if !ok {
+		return sendError(c, "invalid_request", "The task has less recorded time than that", 400)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "time_adjustment_created", client.User.ID, adjustment)
+
+	cfg.refreshGoalProgress(ctx, SID)
+	return nil
+}
+
+// WSOnTimeAdjustRevert undoes an adjustment with a counter-adjustment, leaving both on record
+func (cfg *config) WSOnTimeAdjustRevert(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("time_adjust_revert").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID     uuid.UUID `json:"id"`
+			Reason string    `json:"reason"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	original, err := cfg.DB.GetTimeAdjustmentByID(ctx, payload.Data.ID)
+	if err != nil && err != sql.ErrNoRows {
+		logDBError("Failed to load time adjustment "+payload.Data.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load time adjustment", 500)
+	}
+	if err == sql.ErrNoRows || original.UserID != client.User.ID {
+		return sendError(c, "not_found", "Time adjustment not found", 404)
+	}
+	switch {
+	case original.Kind == "revert":
+		return sendError(c, "invalid_request", "A revert cannot be reverted", 400)
+	case original.RevertedAt.Valid:
+		return sendError(c, "conflict", "Time adjustment was already reverted", 409)
+	}
+
+	reason := strings.TrimSpace(payload.Data.Reason)
+	if len(reason) > maxTimeAdjustReasonLen {
+		return sendError(c, "invalid_request", fmt.Sprintf("reason is limited to %d characters", maxTimeAdjustReasonLen), 400)
+	}
+	if reason == "" {
+		reason = "Reverted: " + original.Reason
+	}
+
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, original.TaskID)
+	if !ok {
+		return err
+	}
+
+	task, revert, ok, err := cfg.saveTimeAdjustment(ctx, SID, task.ID, database.CreateTimeAdjustmentParams{
+		ID:        uuid.New(),
+		TaskID:    task.ID,
+		UserID:    client.User.ID,
+		Kind:      "revert",
+		Seconds:   -original.Seconds,
+		Reason:    reason,
+		RevertsID: uuid.NullUUID{UUID: original.ID, Valid: true},
+		ActorSid:  uuid.NullUUID{UUID: SID, Valid: true},
+	})
+	if err == sql.ErrNoRows {
+		// Another session reverted it first
+		return sendError(c, "conflict", "Time adjustment was already reverted", 409)
+	}
+	if err != nil {
+		logDBError("Failed to revert time adjustment "+original.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to revert time adjustment", 500)
+	}
+	if !ok {
+		return sendError(c, "conflict", "The task no longer has that much recorded time", 409)
+	}
+
+	original, err = cfg.DB.GetTimeAdjustmentByID(ctx, original.ID)
+	logDBError("Failed to reload time adjustment "+original.ID.String(), err)
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "time_adjustment_reverted", client.User.ID, struct {
+		Adjustment database.TimeAdjustment `json:"adjustment"`
+		Revert     database.TimeAdjustment `json:"revert"`
+	}{
+		Adjustment: original,
+		Revert:     revert,
+	})
+
+	cfg.refreshGoalProgress(ctx, SID)
+	return nil
+}
+
+func (cfg *config) WSOnTimeAdjustmentsGet(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("time_adjustments_get").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID uuid.UUID `json:"task_id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	adjustments, err := cfg.DB.GetTimeAdjustmentsByTask(ctx, task.ID)
+	if err != nil {
+		logDBError("Failed to load time adjustments for task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load time adjustments", 500)
+	}
+	if adjustments == nil {
+		adjustments = []database.TimeAdjustment{}
+	}
+
+	// Reverts carry the opposite sign, so the plain sum is what is still applied
+	var adjustedSeconds int64
+	for _, adjustment := range adjustments {
+		adjustedSeconds += adjustment.Seconds
+	}
+
+	return cfg.WSClientManager.SendToClient(ctx, "time_adjustments_get", SID, struct {
+		TaskID          uuid.UUID                 `json:"task_id"`
+		AdjustedSeconds int64                     `json:"adjusted_seconds"`
+		Adjustments     []database.TimeAdjustment `json:"adjustments"`
+	}{
+		TaskID:          task.ID,
+		AdjustedSeconds: adjustedSeconds,
+		Adjustments:     adjustments,
+	})
+}
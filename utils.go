@@ -1,6 +1,14 @@
 package main
 
-import "strings"
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dinopy/taskbar2_server/internal/database"
+)
 
 // detectFrequency parses RRULE string to determine frequency
 func detectFrequency(rrule string) string {
@@ -17,3 +25,135 @@ func detectFrequency(rrule string) string {
 	}
 	return "other"
 }
+
+// taskTrackedSeconds returns the stored duration plus the running segment, if any
+func taskTrackedSeconds(task database.Task, nowMs int64) (int64, error) {
+	durationMs, err := durationStrToInt(task.Duration)
+	if err != nil {
+		return 0, err
+	}
+
+	if task.IsActive && task.ToggledAt.Valid && task.ToggledAt.Int64 != 0 && nowMs > task.ToggledAt.Int64 {
+		durationMs += nowMs - task.ToggledAt.Int64
+	}
+
+	return durationMs / 1000, nil
+}
+
+// defaultUserTimezone matches the column default on users.timezone
+const defaultUserTimezone = "Europe/Bucharest"
+
+// userLocation loads the user's timezone, falling back to the default one
+func userLocation(user database.User) *time.Location {
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		log.Printf("Invalid timezone %q for user %s, using %s", user.Timezone, user.ID, defaultUserTimezone)
+		loc, _ = time.LoadLocation(defaultUserTimezone)
+	}
+	return loc
+}
+
+// userDayBounds returns the start and end of the user's day containing t.
+// A day starts at the user's rollover hour in their timezone.
+func userDayBounds(user database.User, t time.Time) (time.Time, time.Time) {
+	loc := userLocation(user)
+	local := t.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), int(user.RolloverHour), 0, 0, 0, loc)
+	if local.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	end := start.AddDate(0, 0, 1).Add(-time.Second)
+	return start.UTC(), end.UTC()
+}
+
+// userInWorkingHours reports whether t falls inside the user's working hours.
+// Hours that span midnight count towards the day they started on.
+func userInWorkingHours(user database.User, t time.Time) bool {
+	if !user.WorkStartMinutes.Valid || !user.WorkEndMinutes.Valid {
+		return false
+	}
+
+	local := t.In(userLocation(user))
+	minute := int32(local.Hour()*60 + local.Minute())
+	startMinute, endMinute := user.WorkStartMinutes.Int32, user.WorkEndMinutes.Int32
+
+	day := local
+	switch {
+	case startMinute <= endMinute:
+		if minute < startMinute || minute >= endMinute {
+			return false
+		}
+	case minute >= startMinute:
+	case minute < endMinute:
+		day = local.AddDate(0, 0, -1)
+	default:
+		return false
+	}
+
+	weekday := int32(day.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	for _, workDay := range user.WorkDays {
+		if workDay == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// userWorkMinutesLeft returns the minutes until the user's working hours end, and
+// false when t is outside them
+func userWorkMinutesLeft(user database.User, t time.Time) (int64, bool) {
+	if !userInWorkingHours(user, t) {
+		return 0, false
+	}
+
+	local := t.In(userLocation(user))
+	minute := int64(local.Hour()*60 + local.Minute())
+	endMinute := int64(user.WorkEndMinutes.Int32)
+	if endMinute <= minute {
+		// Hours spanning midnight end tomorrow
+		endMinute += 24 * 60
+	}
+	return endMinute - minute, true
+}
+
+// parseClockMinutes turns "HH:MM" into minutes from midnight
+func parseClockMinutes(value string) (int32, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+// formatClockMinutes turns minutes from midnight into "HH:MM", or "" when unset
+func formatClockMinutes(minutes sql.NullInt32) string {
+	if !minutes.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", minutes.Int32/60, minutes.Int32%60)
+}
+
+// userPeriodBounds returns the start (inclusive) and end (exclusive) of the user's
+// day, week or month containing t. Weeks start on Monday; every period starts at
+// the user's rollover hour.
+func userPeriodBounds(user database.User, period string, t time.Time) (time.Time, time.Time) {
+	dayStart, _ := userDayBounds(user, t)
+	local := dayStart.In(userLocation(user))
+
+	var start, end time.Time
+	switch period {
+	case "week":
+		start = local.AddDate(0, 0, -((int(local.Weekday()) + 6) % 7))
+		end = start.AddDate(0, 0, 7)
+	case "month":
+		start = time.Date(local.Year(), local.Month(), 1, int(user.RolloverHour), 0, 0, 0, local.Location())
+		end = start.AddDate(0, 1, 0)
+	default:
+		start = local
+		end = start.AddDate(0, 0, 1)
+	}
+	return start.UTC(), end.UTC()
+}
@@ -64,6 +64,17 @@ func (m *ClientManager) RemoveClient(id uuid.UUID) {
 	log.Println("Client removed:", id)
 }
 
+// UpdateUser refreshes the cached user record on every session of that user.
+func (m *ClientManager) UpdateUser(user database.User) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, client := range m.clients {
+		if client.User.ID == user.ID {
+			client.User = user
+		}
+	}
+}
+
 func (m *ClientManager) Broadcast(ctx context.Context, event string, data []byte) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -214,6 +225,11 @@ func (cfg *config) WebSocketsHandler(w http.ResponseWriter, r *http.Request) {
 				log.Println("Error occured in onTaskCreate function:", err)
 				return
 			}
+		case "task_quick_add":
+			err := cfg.WSOnTaskQuickAdd(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskQuickAdd function:", err)
+			}
 		case "task_toggle":
 			err := cfg.WSOnTaskToggle(ctx, c, SID, data)
 			if err != nil {
@@ -247,11 +263,227 @@ func (cfg *config) WebSocketsHandler(w http.ResponseWriter, r *http.Request) {
 				log.Println("Error occurred in onTaskSplit function:", err)
 				return
 			}
+		case "task_merge":
+			err := cfg.WSOnTaskMerge(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in onTaskMerge function:", err)
+				return
+			}
+		case "task_history":
+			err := cfg.WSOnTaskHistory(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskHistory function:", err)
+			}
+		case "task_revert":
+			err := cfg.WSOnTaskRevert(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskRevert function:", err)
+			}
+		case "task_defer":
+			err := cfg.WSOnTaskDefer(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskDefer function:", err)
+			}
+		case "task_move_state":
+			err := cfg.WSOnTaskMoveState(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskMoveState function:", err)
+			}
+		case "workflow_states_update":
+			err := cfg.WSOnWorkflowStatesUpdate(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnWorkflowStatesUpdate function:", err)
+			}
+		case "task_set_project":
+			err := cfg.WSOnTaskSetProject(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskSetProject function:", err)
+			}
+		case "task_set_budget":
+			err := cfg.WSOnTaskSetBudget(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskSetBudget function:", err)
+			}
+		case "time_adjust":
+			err := cfg.WSOnTimeAdjust(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTimeAdjust function:", err)
+			}
+		case "time_adjust_revert":
+			err := cfg.WSOnTimeAdjustRevert(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTimeAdjustRevert function:", err)
+			}
+		case "time_adjustments_get":
+			err := cfg.WSOnTimeAdjustmentsGet(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTimeAdjustmentsGet function:", err)
+			}
+		case "day_gaps":
+			err := cfg.WSOnDayGaps(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnDayGaps function:", err)
+			}
+		case "project_create":
+			err := cfg.WSOnProjectCreate(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnProjectCreate function:", err)
+			}
+		case "project_edit":
+			err := cfg.WSOnProjectEdit(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnProjectEdit function:", err)
+			}
+		case "project_delete":
+			err := cfg.WSOnProjectDelete(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnProjectDelete function:", err)
+			}
+		case "projects_list":
+			err := cfg.WSOnProjectsList(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnProjectsList function:", err)
+			}
+		case "task_reorder":
+			err := cfg.WSOnTaskReorder(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskReorder function:", err)
+			}
+		case "task_dependency_add":
+			err := cfg.WSOnTaskDependencyAdd(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskDependencyAdd function:", err)
+			}
+		case "task_dependency_remove":
+			err := cfg.WSOnTaskDependencyRemove(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskDependencyRemove function:", err)
+			}
+		case "checklist_item_add":
+			err := cfg.WSOnChecklistItemAdd(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistItemAdd function:", err)
+			}
+		case "checklist_item_edit":
+			err := cfg.WSOnChecklistItemEdit(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistItemEdit function:", err)
+			}
+		case "checklist_item_check":
+			err := cfg.WSOnChecklistItemCheck(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistItemCheck function:", err)
+			}
+		case "checklist_item_uncheck":
+			err := cfg.WSOnChecklistItemUncheck(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistItemUncheck function:", err)
+			}
+		case "checklist_item_reorder":
+			err := cfg.WSOnChecklistItemReorder(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistItemReorder function:", err)
+			}
+		case "checklist_item_delete":
+			err := cfg.WSOnChecklistItemDelete(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistItemDelete function:", err)
+			}
+		case "checklist_get":
+			err := cfg.WSOnChecklistGet(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnChecklistGet function:", err)
+			}
+		case "task_note_add":
+			err := cfg.WSOnTaskNoteAdd(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskNoteAdd function:", err)
+			}
+		case "task_note_edit":
+			err := cfg.WSOnTaskNoteEdit(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskNoteEdit function:", err)
+			}
+		case "task_note_delete":
+			err := cfg.WSOnTaskNoteDelete(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskNoteDelete function:", err)
+			}
+		case "task_notes_get":
+			err := cfg.WSOnTaskNotesGet(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskNotesGet function:", err)
+			}
+		case "task_notes_search":
+			err := cfg.WSOnTaskNotesSearch(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnTaskNotesSearch function:", err)
+			}
+		case "user_settings_update":
+			err := cfg.WSOnUserSettingsUpdate(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnUserSettingsUpdate function:", err)
+			}
 		case "get_completed_tasks":
 			err := cfg.WSOnGetCompletedTasks(ctx, c, SID, data)
 			if err != nil {
 				log.Println("Error occured in OnGetCompletedTasks function: ", err)
 			}
+		case "next_task_suggestions":
+			err := cfg.WSOnNextTaskSuggestions(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnNextTaskSuggestions function:", err)
+			}
+		case "report_fetch":
+			err := cfg.WSOnReportFetch(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnReportFetch function:", err)
+			}
+		case "focus_start":
+			err := cfg.WSOnFocusStart(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnFocusStart function:", err)
+			}
+		case "focus_stop":
+			err := cfg.WSOnFocusStop(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnFocusStop function:", err)
+			}
+		case "activity":
+			err := cfg.WSOnActivity(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnActivity function:", err)
+			}
+		case "idle_resolve":
+			err := cfg.WSOnIdleResolve(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnIdleResolve function:", err)
+			}
+		case "goal_create":
+			err := cfg.WSOnGoalCreate(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnGoalCreate function:", err)
+			}
+		case "goal_edit":
+			err := cfg.WSOnGoalEdit(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnGoalEdit function:", err)
+			}
+		case "goal_delete":
+			err := cfg.WSOnGoalDelete(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnGoalDelete function:", err)
+			}
+		case "goals_list":
+			err := cfg.WSOnGoalsList(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnGoalsList function:", err)
+			}
+		case "achievements_list":
+			err := cfg.WSOnAchievementsList(ctx, c, SID, data)
+			if err != nil {
+				log.Println("Error occurred in OnAchievementsList function:", err)
+			}
 		case "request_hard_refresh":
 			err := cfg.WSOnRequestHardRefresh(ctx, c, SID, data)
 			if err != nil {
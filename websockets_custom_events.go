@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -130,7 +132,7 @@ func (cfg *config) WSOnConnect(ctx context.Context, c *websocket.Conn, SID uuid.
 		User: user,
 	})
 
-	tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(ctx, user.ID)
+	tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(ctx, database.GetActiveTaskByUUIDParams{UserID: user.ID})
 	if err != nil {
 		logDBError("Failed to load tasks for user "+user.ID.String(), err)
 		return sendError(c, ErrorDatabaseError, "Failed to load tasks", 500)
@@ -181,6 +183,45 @@ func (cfg *config) WSOnConnect(ctx context.Context, c *websocket.Conn, SID uuid.
 		return sendError(c, ErrorDatabaseError, "Failed to load schedules", 500)
 	}
 
+	taskDependencies, err := cfg.DB.GetTaskDependenciesByUser(ctx, user.ID)
+	if err != nil {
+		logDBError("Failed to load task dependencies for user "+user.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load task dependencies", 500)
+	}
+
+	checklistItems, err := cfg.DB.GetActiveChecklistItemsByUser(ctx, user.ID)
+	if err != nil {
+		logDBError("Failed to load checklist items for user "+user.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load checklist items", 500)
+	}
+
+	overdueCount, err := cfg.DB.CountOverdueTasksByUser(ctx, user.ID)
+	if err != nil {
+		logDBError("Failed to count overdue tasks for user "+user.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load tasks", 500)
+	}
+
+	workflowStates, err := cfg.DB.GetWorkflowStatesByUser(ctx, user.ID)
+	if err != nil {
+		logDBError("Failed to load workflow states for user "+user.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load workflow states", 500)
+	}
+
+	projects, err := cfg.DB.GetProjectsByUser(ctx, user.ID)
+	if err != nil {
+		logDBError("Failed to load projects for user "+user.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load projects", 500)
+	}
+
+	var focusSession *database.FocusSession
+	runningFocusSession, err := cfg.DB.GetRunningFocusSessionByUser(ctx, user.ID)
+	if err == nil {
+		focusSession = &runningFocusSession
+	} else if err != sql.ErrNoRows {
+		logDBError("Failed to load focus session for user "+user.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load focus session", 500)
+	}
+
 	var category string
 	var keyCommands string
 
@@ -192,19 +233,26 @@ func (cfg *config) WSOnConnect(ctx context.Context, c *websocket.Conn, SID uuid.
 	}
 
 	type finalUser struct {
-		SID                    uuid.UUID               `json:"sid"`
-		ID                     uuid.UUID               `json:"id"`
-		FirstName              string                  `json:"first_name"`
-		LastName               string                  `json:"last_name"`
-		Email                  string                  `json:"email"`
-		CreatedAt              time.Time               `json:"created_at"`
-		UpdatedAt              time.Time               `json:"updated_at"`
-		Categories             string                  `json:"categories"`
-		KeyCommands            string                  `json:"key_commands"`
-		Tasks                  []database.Task         `json:"tasks"`
-		Notifications          []database.Notification `json:"notifications"`
-		NotificationsUnseenCnt int64                   `json:"notifications_unseen_count"`
-		Schedules              []database.Schedule     `json:"schedules"`
+		SID                    uuid.UUID                    `json:"sid"`
+		ID                     uuid.UUID                    `json:"id"`
+		FirstName              string                       `json:"first_name"`
+		LastName               string                       `json:"last_name"`
+		Email                  string                       `json:"email"`
+		CreatedAt              time.Time                    `json:"created_at"`
+		UpdatedAt              time.Time                    `json:"updated_at"`
+		Categories             string                       `json:"categories"`
+		KeyCommands            string                       `json:"key_commands"`
+		Tasks                  []database.Task              `json:"tasks"`
+		Notifications          []database.Notification      `json:"notifications"`
+		NotificationsUnseenCnt int64                        `json:"notifications_unseen_count"`
+		Schedules              []database.Schedule          `json:"schedules"`
+		TaskDependencies       []database.TaskDependency    `json:"task_dependencies"`
+		ChecklistItems         []database.TaskChecklistItem `json:"checklist_items"`
+		WorkflowStates         []database.WorkflowState     `json:"workflow_states"`
+		Projects               []database.Project           `json:"projects"`
+		FocusSession           *database.FocusSession       `json:"focus_session"`
+		OverdueTasksCount      int64                        `json:"overdue_tasks_count"`
+		userSettings
 	}
 
 	cfg.WSClientManager.SendToClient(ctx, "connected", SID, finalUser{
@@ -221,6 +269,13 @@ func (cfg *config) WSOnConnect(ctx context.Context, c *websocket.Conn, SID uuid.
 		Notifications:          notifications,
 		NotificationsUnseenCnt: unseenCount,
 		Schedules:              schedules,
+		TaskDependencies:       taskDependencies,
+		ChecklistItems:         checklistItems,
+		WorkflowStates:         workflowStates,
+		Projects:               projects,
+		FocusSession:           focusSession,
+		OverdueTasksCount:      overdueCount,
+		userSettings:           newUserSettings(user),
 	})
 	return nil
 }
@@ -247,6 +302,7 @@ func (cfg *config) WSOnTaskCreate(ctx context.Context, c *websocket.Conn, SID uu
 		Priority          *int32     `json:"priority"`
 		DueAt             *time.Time `json:"due_at"`
 		ShowBeforeDueTime *int32     `json:"show_before_due_time"`
+		EstimateMinutes   *int32     `json:"estimate_minutes"`
 	}
 	var connectionData struct {
 		Data taskT `json:"data"`
@@ -288,6 +344,8 @@ func (cfg *config) WSOnTaskCreate(ctx context.Context, c *websocket.Conn, SID uu
 		}
 	}
 
+	estimateMinutes := nullableEstimate(connectionData.Data.EstimateMinutes)
+
 	task, err := cfg.DB.CreateTaskWithTiming(ctx, database.CreateTaskParams{
 		ID:          connectionData.Data.ID,
 		Title:       connectionData.Data.Title,
@@ -311,12 +369,16 @@ func (cfg *config) WSOnTaskCreate(ctx context.Context, c *websocket.Conn, SID uu
 		Priority:          priority,
 		DueAt:             dueAt,
 		ShowBeforeDueTime: showBeforeDueTime,
+		EstimateMinutes:   estimateMinutes,
 	})
 
 	if err != nil {
 		return err
 	}
 
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "create", nil, task)
+	planTaskDueJobs(ctx, cfg.DB, task)
+
 	cfg.WSClientManager.BroadcastToSameUserNoIssuer(
 		ctx,
 		"new_task_created",
@@ -350,6 +412,11 @@ func (cfg *config) WSOnTaskToggle(ctx context.Context, c *websocket.Conn, SID uu
 		return err
 	}
 
+	previousTask, err := cfg.DB.GetTaskByIDWithTiming(ctx, connectionData.Data.UUID)
+	if err != nil {
+		return err
+	}
+
 	task, err := cfg.DB.ToggleTaskWithTiming(ctx, database.ToggleTaskParams{
 		ID: connectionData.Data.UUID,
 		ToggledAt: sql.NullInt64{
@@ -363,6 +430,9 @@ func (cfg *config) WSOnTaskToggle(ctx context.Context, c *websocket.Conn, SID uu
 	if err != nil {
 		return err
 	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "toggle", &previousTask, task)
+
 	cfg.WSClientManager.BroadcastToSameUserNoIssuer(
 		ctx,
 		"related_task_toggled",
@@ -370,6 +440,8 @@ func (cfg *config) WSOnTaskToggle(ctx context.Context, c *websocket.Conn, SID uu
 		SID,
 		task,
 	)
+
+	cfg.refreshGoalProgress(ctx, SID)
 	return nil
 }
 
@@ -395,6 +467,11 @@ func (cfg *config) WSOnTaskCompleted(ctx context.Context, c *websocket.Conn, SID
 	}
 	fmt.Println(connectionData)
 
+	previousTask, err := cfg.DB.GetTaskByIDWithTiming(ctx, connectionData.Data.ID)
+	if err != nil {
+		return err
+	}
+
 	task, err := cfg.DB.CompleteTaskWithTiming(ctx, database.CompleteTaskParams{
 		ID:       connectionData.Data.ID,
 		Duration: connectionData.Data.Duration,
@@ -404,6 +481,11 @@ func (cfg *config) WSOnTaskCompleted(ctx context.Context, c *websocket.Conn, SID
 		},
 		LastModifiedAt: connectionData.Data.LastModifiedAt,
 	})
+	if err == nil {
+		recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "complete", &previousTask, task)
+		cancelTaskDueJobs(ctx, cfg.DB, task.ID)
+		cfg.releaseDependentTasks(ctx, task.ID)
+	}
 
 	cfg.WSClientManager.BroadcastToSameUserNoIssuer(
 		ctx,
@@ -417,6 +499,7 @@ func (cfg *config) WSOnTaskCompleted(ctx context.Context, c *websocket.Conn, SID
 		},
 	)
 
+	cfg.refreshGoalProgress(ctx, SID)
 	return nil
 }
 
@@ -436,6 +519,7 @@ func (cfg *config) WSOnTaskEdit(ctx context.Context, c *websocket.Conn, SID uuid
 		Priority          *int32     `json:"priority"`
 		DueAt             *time.Time `json:"due_at"`
 		ShowBeforeDueTime *int32     `json:"show_before_due_time"`
+		EstimateMinutes   *int32     `json:"estimate_minutes"`
 	}
 
 	var connectionData struct {
@@ -473,6 +557,13 @@ func (cfg *config) WSOnTaskEdit(ctx context.Context, c *websocket.Conn, SID uuid
 		}
 	}
 
+	estimateMinutes := nullableEstimate(connectionData.Data.EstimateMinutes)
+
+	previousTask, err := cfg.DB.GetTaskByIDWithTiming(ctx, connectionData.Data.ID)
+	if err != nil {
+		return err
+	}
+
 	task, err := cfg.DB.EditTaskWithTiming(ctx, database.EditTaskParams{
 		ID:                connectionData.Data.ID,
 		Title:             connectionData.Data.Title,
@@ -483,7 +574,14 @@ func (cfg *config) WSOnTaskEdit(ctx context.Context, c *websocket.Conn, SID uuid
 		Priority:          priority,
 		DueAt:             dueAt,
 		ShowBeforeDueTime: showBeforeDueTime,
+		EstimateMinutes:   estimateMinutes,
 	})
+	if err == nil {
+		recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "edit", &previousTask, task)
+		if dueRemindersChanged(previousTask, task) {
+			planTaskDueJobs(ctx, cfg.DB, task)
+		}
+	}
 
 	cfg.WSClientManager.BroadcastToSameUserNoIssuer(
 		ctx,
@@ -492,6 +590,8 @@ func (cfg *config) WSOnTaskEdit(ctx context.Context, c *websocket.Conn, SID uuid
 		SID,
 		task,
 	)
+
+	cfg.refreshGoalProgress(ctx, SID)
 	return nil
 }
 
@@ -513,10 +613,20 @@ func (cfg *config) WSOnTaskDelete(ctx context.Context, c *websocket.Conn, SID uu
 		return err
 	}
 
+	// Dependency rows cascade with the task, so remember who was waiting on it
+	dependentIDs, err := cfg.DB.GetDependentTaskIDs(ctx, connectionData.Data.ID)
+	if err != nil {
+		return err
+	}
+
+	// Jobs of the task itself cascade, a linked schedule occurrence's do not
+	cancelTaskDueJobs(ctx, cfg.DB, connectionData.Data.ID)
+
 	err = cfg.DB.DeleteTaskWithTiming(ctx, connectionData.Data.ID)
 	if err != nil {
 		return err
 	}
+	cfg.refreshDependentTasks(ctx, dependentIDs)
 
 	cfg.WSClientManager.BroadcastToSameUserNoIssuer(
 		ctx,
@@ -529,6 +639,8 @@ func (cfg *config) WSOnTaskDelete(ctx context.Context, c *websocket.Conn, SID uu
 			ID: connectionData.Data.ID,
 		},
 	)
+
+	cfg.refreshGoalProgress(ctx, SID)
 	return nil
 }
 
@@ -544,6 +656,8 @@ func (cfg *config) WSOnGetCompletedTasks(ctx context.Context, c *websocket.Conn,
 		EndDate     time.Time `json:"end_date"`
 		SearchQuery string    `json:"search_query"`
 		Tags        []string  `json:"tags"`
+		Overdue     *bool     `json:"overdue"`
+		StateID     uuid.UUID `json:"state_id"`
 	}
 
 	var connectionData struct {
@@ -556,8 +670,11 @@ func (cfg *config) WSOnGetCompletedTasks(ctx context.Context, c *websocket.Conn,
 	}
 	fmt.Printf("Data received from the app: \n%+v\n\n", connectionData.Data)
 
+	user := cfg.WSClientManager.clients[SID].User
+
+	// Day boundaries follow the user's timezone and rollover hour
 	queryFilters := database.GetCompletedTasksByUUIDParams{}
-	queryFilters.UserID = cfg.WSClientManager.clients[SID].User.ID
+	queryFilters.UserID = user.ID
 	queryFilters.Tags = connectionData.Data.Tags
 	if !connectionData.Data.StartDate.IsZero() {
 		queryFilters.StartDate = sql.NullTime{
@@ -565,33 +682,20 @@ func (cfg *config) WSOnGetCompletedTasks(ctx context.Context, c *websocket.Conn,
 			Time:  connectionData.Data.StartDate.In(time.UTC),
 		}
 	} else {
-		now := time.Now()
-		startOfDay := time.Date(
-			now.Year(), now.Month(), now.Day(),
-			0, 0, 0, 0, time.UTC,
-		)
+		startOfDay, _ := userDayBounds(user, time.Now())
 		queryFilters.StartDate = sql.NullTime{
 			Valid: true,
 			Time:  startOfDay,
 		}
 	}
 	if !connectionData.Data.EndDate.IsZero() {
-		endDateWithTime := time.Date(
-			connectionData.Data.EndDate.Year(),
-			connectionData.Data.EndDate.Month(),
-			connectionData.Data.EndDate.Day(),
-			23, 59, 59, 0, time.UTC,
-		)
+		_, endDateWithTime := userDayBounds(user, connectionData.Data.EndDate)
 		queryFilters.EndDate = sql.NullTime{
 			Valid: true,
 			Time:  endDateWithTime,
 		}
 	} else {
-		now := time.Now()
-		endOfDay := time.Date(
-			now.Year(), now.Month(), now.Day(),
-			23, 59, 59, 0, time.UTC,
-		)
+		_, endOfDay := userDayBounds(user, time.Now())
 		queryFilters.EndDate = sql.NullTime{
 			Valid: true,
 			Time:  endOfDay,
@@ -609,6 +713,18 @@ func (cfg *config) WSOnGetCompletedTasks(ctx context.Context, c *websocket.Conn,
 			Valid:  true,
 		}
 	}
+	if connectionData.Data.Overdue != nil {
+		queryFilters.Overdue = sql.NullBool{
+			Bool:  *connectionData.Data.Overdue,
+			Valid: true,
+		}
+	}
+	if connectionData.Data.StateID != uuid.Nil {
+		queryFilters.StateID = uuid.NullUUID{
+			UUID:  connectionData.Data.StateID,
+			Valid: true,
+		}
+	}
 	fmt.Printf("Final filters used for the query:\n%+v\n\n", queryFilters)
 
 	tasks, err := cfg.DB.GetCompletedTasksByUUIDWithTiming(ctx, queryFilters)
@@ -617,6 +733,45 @@ func (cfg *config) WSOnGetCompletedTasks(ctx context.Context, c *websocket.Conn,
 	}
 	cfg.WSClientManager.SendToClient(ctx, "get_completed_tasks", SID, tasks)
 
+	// Days closed out by a 'continue' rollover, as per-day slices of still open tasks
+	segments, err := cfg.DB.GetTaskSegmentsByUUID(ctx, database.GetTaskSegmentsByUUIDParams{
+		UserID:      queryFilters.UserID,
+		StartDate:   queryFilters.StartDate,
+		EndDate:     queryFilters.EndDate,
+		Tags:        queryFilters.Tags,
+		SearchQuery: queryFilters.SearchQuery,
+		Category:    queryFilters.Category,
+		Overdue:     queryFilters.Overdue,
+		StateID:     queryFilters.StateID,
+	})
+	if err != nil {
+		return err
+	}
+	cfg.WSClientManager.SendToClient(ctx, "get_completed_task_segments", SID, segments)
+
+	// The notes of every task above, so exports carry the work log as well
+	taskIDs := make([]uuid.UUID, 0, len(tasks)+len(segments))
+	seen := make(map[uuid.UUID]struct{}, len(tasks)+len(segments))
+	for _, task := range tasks {
+		seen[task.ID] = struct{}{}
+		taskIDs = append(taskIDs, task.ID)
+	}
+	for _, segment := range segments {
+		if _, ok := seen[segment.TaskID]; !ok {
+			seen[segment.TaskID] = struct{}{}
+			taskIDs = append(taskIDs, segment.TaskID)
+		}
+	}
+
+	notes := []database.TaskNote{}
+	if len(taskIDs) > 0 {
+		notes, err = cfg.DB.GetTaskNotesByTaskIDs(ctx, taskIDs)
+		if err != nil {
+			return err
+		}
+	}
+	cfg.WSClientManager.SendToClient(ctx, "get_completed_task_notes", SID, notes)
+
 	return nil
 }
 
@@ -645,22 +800,55 @@ func (cfg *config) WSOnRequestHardRefresh(ctx context.Context, c *websocket.Conn
 		metrics.WebSocketEventDuration.WithLabelValues("hard_refresh").Observe(time.Since(start).Seconds())
 	}()
 
+	// Optional filters, e.g. for a board showing only overdue tasks or one workflow state
+	var payload struct {
+		Data struct {
+			Overdue *bool     `json:"overdue"`
+			StateID uuid.UUID `json:"state_id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
 	settings, err := cfg.DB.GetUserSettingsWithTiming(ctx, cfg.WSClientManager.clients[SID].User.ID)
 	if err != nil {
 		return nil
 	}
 
-	tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(ctx, cfg.WSClientManager.clients[SID].User.ID)
+	taskFilters := database.GetActiveTaskByUUIDParams{UserID: cfg.WSClientManager.clients[SID].User.ID}
+	if payload.Data.Overdue != nil {
+		taskFilters.Overdue = sql.NullBool{
+			Bool:  *payload.Data.Overdue,
+			Valid: true,
+		}
+	}
+	if payload.Data.StateID != uuid.Nil {
+		taskFilters.StateID = uuid.NullUUID{
+			UUID:  payload.Data.StateID,
+			Valid: true,
+		}
+	}
+
+	tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(ctx, taskFilters)
+	if err != nil {
+		return nil
+	}
+
+	checklistItems, err := cfg.DB.GetActiveChecklistItemsByUser(ctx, cfg.WSClientManager.clients[SID].User.ID)
 	if err != nil {
 		return nil
 	}
 
 	response := struct {
-		Categories  string          `json:"categories"`
-		KeyCommands string          `json:"key_commands"`
-		Tasks       []database.Task `json:"tasks"`
+		Categories     string                       `json:"categories"`
+		KeyCommands    string                       `json:"key_commands"`
+		Tasks          []database.Task              `json:"tasks"`
+		ChecklistItems []database.TaskChecklistItem `json:"checklist_items"`
 	}{
-		Tasks: tasks,
+		Tasks:          tasks,
+		ChecklistItems: checklistItems,
 	}
 
 	if settings.Categories.Valid {
@@ -709,6 +897,243 @@ func (cfg *config) WSOnUserUpdatedCategories(ctx context.Context, c *websocket.C
 	return nil
 }
 
+// maxOverdueEscalationSteps caps the overdue_escalation_minutes setting
+const maxOverdueEscalationSteps = 10
+
+// userSettings is the settings part of the user sent on connect and after user_settings_update
+type userSettings struct {
+	Timezone             string  `json:"timezone"`
+	RolloverHour         int32   `json:"rollover_hour"`
+	RolloverMode         string  `json:"rollover_mode"`
+	IdleThresholdMinutes int32   `json:"idle_threshold_minutes"`
+	WorkStart            string  `json:"work_start"`
+	WorkEnd              string  `json:"work_end"`
+	WorkDays             []int32 `json:"work_days"`
+	NudgeAfterMinutes    int32   `json:"nudge_after_minutes"`
+	NudgeIntervalMinutes int32   `json:"nudge_interval_minutes"`
+	OverdueEscalation    []int32 `json:"overdue_escalation_minutes"`
+	DayGapsNotify        bool    `json:"day_gaps_notify"`
+}
+
+func newUserSettings(user database.User) userSettings {
+	return userSettings{
+		Timezone:             user.Timezone,
+		RolloverHour:         user.RolloverHour,
+		RolloverMode:         user.RolloverMode,
+		IdleThresholdMinutes: user.IdleThresholdMinutes,
+		WorkStart:            formatClockMinutes(user.WorkStartMinutes),
+		WorkEnd:              formatClockMinutes(user.WorkEndMinutes),
+		WorkDays:             user.WorkDays,
+		NudgeAfterMinutes:    user.NudgeAfterMinutes,
+		NudgeIntervalMinutes: user.NudgeIntervalMinutes,
+		OverdueEscalation:    user.OverdueEscalationMinutes,
+		DayGapsNotify:        user.DayGapsNotify,
+	}
+}
+
+func (cfg *config) WSOnUserSettingsUpdate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("user_settings_update").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			Timezone             *string `json:"timezone"`
+			RolloverHour         *int32  `json:"rollover_hour"`
+			RolloverMode         *string `json:"rollover_mode"`
+			IdleThresholdMinutes *int32  `json:"idle_threshold_minutes"`
+			WorkStart            *string `json:"work_start"`
+			WorkEnd              *string `json:"work_end"`
+			WorkDays             []int32 `json:"work_days"`
+			NudgeAfterMinutes    *int32  `json:"nudge_after_minutes"`
+			NudgeIntervalMinutes *int32  `json:"nudge_interval_minutes"`
+			OverdueEscalation    []int32 `json:"overdue_escalation_minutes"`
+			DayGapsNotify        *bool   `json:"day_gaps_notify"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	timezone := client.User.Timezone
+	if payload.Data.Timezone != nil {
+		timezone = *payload.Data.Timezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return sendError(c, "invalid_request", "Unknown timezone", 400)
+	}
+
+	rolloverHour := client.User.RolloverHour
+	if payload.Data.RolloverHour != nil {
+		rolloverHour = *payload.Data.RolloverHour
+	}
+	if rolloverHour < 0 || rolloverHour > 23 {
+		return sendError(c, "invalid_request", "Rollover hour must be between 0 and 23", 400)
+	}
+
+	rolloverMode := client.User.RolloverMode
+	if payload.Data.RolloverMode != nil {
+		rolloverMode = *payload.Data.RolloverMode
+	}
+	if rolloverMode != "clone" && rolloverMode != "continue" {
+		return sendError(c, "invalid_request", "Rollover mode must be 'clone' or 'continue'", 400)
+	}
+
+	// Every write below commits together, so a rejected field leaves all settings as they were
+	tx, err := cfg.DBPool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := cfg.DB.WithTx(tx)
+
+	if payload.Data.IdleThresholdMinutes != nil {
+		if *payload.Data.IdleThresholdMinutes < 0 {
+			return sendError(c, "invalid_request", "Idle threshold cannot be negative", 400)
+		}
+		err := queries.UpdateUserIdleThreshold(ctx, database.UpdateUserIdleThresholdParams{
+			ID:                   client.User.ID,
+			IdleThresholdMinutes: *payload.Data.IdleThresholdMinutes,
+		})
+		if err != nil {
+			logDBError("Failed to update idle threshold for user "+client.User.ID.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to update settings", 500)
+		}
+	}
+
+	if payload.Data.WorkStart != nil || payload.Data.WorkEnd != nil || payload.Data.WorkDays != nil ||
+		payload.Data.NudgeAfterMinutes != nil || payload.Data.NudgeIntervalMinutes != nil {
+		params := database.UpdateUserWorkingHoursParams{
+			ID:                   client.User.ID,
+			WorkStartMinutes:     client.User.WorkStartMinutes,
+			WorkEndMinutes:       client.User.WorkEndMinutes,
+			WorkDays:             client.User.WorkDays,
+			NudgeAfterMinutes:    client.User.NudgeAfterMinutes,
+			NudgeIntervalMinutes: client.User.NudgeIntervalMinutes,
+		}
+
+		// An empty string clears the bound, which turns nudges off
+		for _, bound := range []struct {
+			value  *string
+			target *sql.NullInt32
+		}{
+			{payload.Data.WorkStart, &params.WorkStartMinutes},
+			{payload.Data.WorkEnd, &params.WorkEndMinutes},
+		} {
+			if bound.value == nil {
+				continue
+			}
+			if *bound.value == "" {
+				*bound.target = sql.NullInt32{Valid: false}
+				continue
+			}
+			minutes, err := parseClockMinutes(*bound.value)
+			if err != nil {
+				return sendError(c, "invalid_request", "Working hours must be formatted as HH:MM", 400)
+			}
+			*bound.target = sql.NullInt32{Int32: minutes, Valid: true}
+		}
+
+		if payload.Data.WorkDays != nil {
+			for _, day := range payload.Data.WorkDays {
+				if day < 1 || day > 7 {
+					return sendError(c, "invalid_request", "Work days must be between 1 (Monday) and 7 (Sunday)", 400)
+				}
+			}
+			params.WorkDays = payload.Data.WorkDays
+		}
+		if payload.Data.NudgeAfterMinutes != nil {
+			params.NudgeAfterMinutes = *payload.Data.NudgeAfterMinutes
+		}
+		if payload.Data.NudgeIntervalMinutes != nil {
+			params.NudgeIntervalMinutes = *payload.Data.NudgeIntervalMinutes
+		}
+		if params.NudgeAfterMinutes < 1 || params.NudgeIntervalMinutes < 1 {
+			return sendError(c, "invalid_request", "Nudge minutes must be at least 1", 400)
+		}
+
+		if err := queries.UpdateUserWorkingHours(ctx, params); err != nil {
+			logDBError("Failed to update working hours for user "+client.User.ID.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to update settings", 500)
+		}
+	}
+
+	if payload.Data.OverdueEscalation != nil {
+		steps := make([]int32, len(payload.Data.OverdueEscalation))
+		copy(steps, payload.Data.OverdueEscalation)
+		sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+		if len(steps) > maxOverdueEscalationSteps {
+			return sendError(c, "invalid_request", fmt.Sprintf("At most %d overdue escalation steps are allowed", maxOverdueEscalationSteps), 400)
+		}
+		for i, minutes := range steps {
+			if minutes < 0 {
+				return sendError(c, "invalid_request", "Overdue escalation minutes cannot be negative", 400)
+			}
+			if i > 0 && minutes == steps[i-1] {
+				return sendError(c, "invalid_request", "Overdue escalation minutes must be unique", 400)
+			}
+		}
+
+		// An empty list turns overdue notifications off
+		err := queries.UpdateUserOverdueEscalation(ctx, database.UpdateUserOverdueEscalationParams{
+			ID:                       client.User.ID,
+			OverdueEscalationMinutes: steps,
+		})
+		if err != nil {
+			logDBError("Failed to update overdue escalation for user "+client.User.ID.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to update settings", 500)
+		}
+	}
+
+	if payload.Data.DayGapsNotify != nil {
+		err := queries.UpdateUserDayGapsNotify(ctx, database.UpdateUserDayGapsNotifyParams{
+			ID:            client.User.ID,
+			DayGapsNotify: *payload.Data.DayGapsNotify,
+		})
+		if err != nil {
+			logDBError("Failed to update day gaps notify for user "+client.User.ID.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to update settings", 500)
+		}
+	}
+
+	// Changing the day boundary also restarts the rollover clock, so moving it never
+	// closes the day early. Other settings leave the clock alone, or saving one just
+	// before the boundary would skip that day's rollover.
+	var user database.User
+	if timezone != client.User.Timezone || rolloverHour != client.User.RolloverHour || rolloverMode != client.User.RolloverMode {
+		user, err = queries.UpdateUserTimeSettings(ctx, database.UpdateUserTimeSettingsParams{
+			ID:           client.User.ID,
+			Timezone:     timezone,
+			RolloverHour: rolloverHour,
+			RolloverMode: rolloverMode,
+		})
+	} else {
+		user, err = queries.GetUserByID(ctx, client.User.ID)
+	}
+	if err != nil {
+		logDBError("Failed to update settings for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to update settings", 500)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logDBError("Failed to update settings for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to update settings", 500)
+	}
+
+	cfg.WSClientManager.UpdateUser(user)
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "user_settings_updated", user.ID, newUserSettings(user))
+	return nil
+}
+
 func (cfg *config) WSOnNewCommandAdded(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	var connectionData struct {
 		Data string `json:"data"`
@@ -770,6 +1195,14 @@ func durationIntToStr(duration int64) (string, error) {
 	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds), nil
 }
 
+// nullableEstimate treats a missing or non-positive estimate as "no estimate"
+func nullableEstimate(minutes *int32) sql.NullInt32 {
+	if minutes == nil || *minutes <= 0 {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *minutes, Valid: true}
+}
+
 func ternary[T any](condition bool, ifTrue T, ifFalse T) T {
 	if condition {
 		return ifTrue
@@ -777,22 +1210,34 @@ func ternary[T any](condition bool, ifTrue T, ifFalse T) T {
 	return ifFalse
 }
 
+// WSOnMidnightTaskRefresh runs every minute and rolls over the tasks of each user
+// whose local rollover time (one minute before their rollover hour) has passed.
 func (cfg *config) WSOnMidnightTaskRefresh() {
-	userIDs := make(map[uuid.UUID]struct{})
-	loc, _ := time.LoadLocation("Europe/Bucharest")
-	timeNow := time.Now().In(loc)
-	lastEpochMs := time.Now().UnixMilli()
+	users, err := cfg.DB.GetUsersDueForRollover(context.Background())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, user := range users {
+		cfg.rolloverUserTasks(user)
+	}
+}
+
+func (cfg *config) rolloverUserTasks(user database.User) {
+	timeNow := time.Now()
+	lastEpochMs := timeNow.UnixMilli()
 
-	// get all the tasks that are not completed from db
-	tasks, err := cfg.DB.GetNonCompletedTasksWithTiming(context.Background())
+	log.Printf("Rolling over tasks for user %s (%s, hour %d)", user.ID, user.Timezone, user.RolloverHour)
+
+	// get all the tasks of the user that are not completed from db, deferred ones included
+	tasks, err := cfg.DB.GetOpenTasksByUser(context.Background(), user.ID)
 	if err != nil {
 		log.Println(err)
+		return
 	}
 
 	for _, task := range tasks {
-		// save the id of the user, we must try to send the refresher to.
-		userIDs[task.UserID] = struct{}{}
-
 		// calculate duration to int
 		durationInt, err := durationStrToInt(task.Duration)
 		if err != nil {
@@ -822,6 +1267,11 @@ func (cfg *config) WSOnMidnightTaskRefresh() {
 			log.Println(err)
 		}
 
+		if user.RolloverMode == "continue" {
+			cfg.closeOutTaskDay(user, task, durationStr, timeNow)
+			continue
+		}
+
 		// update current task to new duration, complete status, last modified at , completed_at
 		completeTaskParams := database.CompleteTaskParams{
 			ID:       task.ID,
@@ -833,9 +1283,12 @@ func (cfg *config) WSOnMidnightTaskRefresh() {
 			LastModifiedAt: lastEpochMs,
 		}
 
-		_, err = cfg.DB.CompleteTaskWithTiming(context.Background(), completeTaskParams)
+		completedTask, err := cfg.DB.CompleteTaskWithTiming(context.Background(), completeTaskParams)
 		if err != nil {
 			log.Println(err)
+		} else {
+			recordTaskRevision(context.Background(), cfg.DB, uuid.NullUUID{}, "complete", &task, completedTask)
+			cancelTaskDueJobs(context.Background(), cfg.DB, task.ID)
 		}
 
 		// insert a new task with the same properties
@@ -861,76 +1314,228 @@ func (cfg *config) WSOnMidnightTaskRefresh() {
 			Priority:          task.Priority,          // Copy from original task
 			DueAt:             task.DueAt,             // Copy from original task
 			ShowBeforeDueTime: task.ShowBeforeDueTime, // Copy from original task
+			EstimateMinutes:   task.EstimateMinutes,   // Copy from original task
+			ContinuationOf:    uuid.NullUUID{UUID: task.ID, Valid: true},
 		}
 
-		_, err = cfg.DB.CreateTaskWithTiming(context.Background(), createTaskParams)
+		clonedTask, err := cfg.DB.CreateTaskWithTiming(context.Background(), createTaskParams)
 		if err != nil {
 			log.Println(err)
-		}
-	}
-
-	// emit a refresher to all connected devices
-	for _, client := range cfg.WSClientManager.clients {
-		if _, ok := userIDs[client.User.ID]; !ok {
-			continue
-		}
-		log.Printf("Currently processing client SID: %s\n", client.SID.String())
+		} else {
+			recordTaskRevision(context.Background(), cfg.DB, uuid.NullUUID{}, "create", nil, clonedTask)
+			planTaskDueJobs(context.Background(), cfg.DB, clonedTask)
+
+			// keep the clone where the original sat in the list
+			_, err = cfg.DB.SetTaskSortPosition(context.Background(), database.SetTaskSortPositionParams{
+				ID:             clonedTask.ID,
+				SortPosition:   task.SortPosition,
+				LastModifiedAt: lastEpochMs,
+			})
+			if err != nil {
+				log.Println(err)
+			}
 
-		tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(context.Background(), client.User.ID)
-		if err != nil {
-			log.Println(err)
-		}
+			// the clone carries on the work, so it inherits the original's dependencies
+			err = cfg.DB.ReassignTaskDependencies(context.Background(), database.ReassignTaskDependenciesParams{
+				OldTaskID: task.ID,
+				NewTaskID: clonedTask.ID,
+			})
+			if err != nil {
+				log.Println(err)
+			} else if _, err := cfg.DB.RefreshTaskBlocked(context.Background(), clonedTask.ID); err != nil {
+				log.Println(err)
+			}
 
-		user, err := cfg.DB.GetUserSettingsWithTiming(context.Background(), client.User.ID)
-		if err != nil {
-			log.Println(err)
-		}
+			// the checklist carries over as it was, checked items included
+			err = cfg.DB.CopyChecklistItems(context.Background(), database.CopyChecklistItemsParams{
+				TargetTaskID: clonedTask.ID,
+				KeepChecked:  true,
+				SourceTaskID: task.ID,
+			})
+			if err != nil {
+				log.Println(err)
+			} else if _, err := cfg.DB.RefreshTaskChecklistCounts(context.Background(), clonedTask.ID); err != nil {
+				log.Println(err)
+			}
 
-		var category string
-		var keyCommands string
+			// a deferred task stays out of sight after the rollover
+			if task.HiddenUntil.Valid {
+				_, err = cfg.DB.SetTaskHiddenUntil(context.Background(), database.SetTaskHiddenUntilParams{
+					ID:             clonedTask.ID,
+					HiddenUntil:    task.HiddenUntil,
+					LastModifiedAt: lastEpochMs,
+				})
+				if err != nil {
+					log.Println(err)
+				}
+			}
 
-		if user.Categories.Valid {
-			category = user.Categories.String
-		}
-		if user.KeyCommands.Valid {
-			keyCommands = user.KeyCommands.String
-		}
+			// an overdue task stays overdue, without repeating alerts already sent
+			if task.OverdueSince.Valid {
+				err = cfg.DB.SetTaskOverdueState(context.Background(), database.SetTaskOverdueStateParams{
+					ID:                   clonedTask.ID,
+					OverdueSince:         task.OverdueSince,
+					OverdueNotifiedCount: task.OverdueNotifiedCount,
+				})
+				if err != nil {
+					log.Println(err)
+				}
+			}
 
-		type refresher struct {
-			Categories  string          `json:"categories"`
-			KeyCommands string          `json:"key_commands"`
-			Tasks       []database.Task `json:"tasks"`
-		}
+			// the estimate counts the whole chain, so alerts already sent are not repeated
+			if task.EstimateWarnedAt.Valid || task.EstimateExceededAt.Valid {
+				err = cfg.DB.SetTaskEstimateAlerts(context.Background(), database.SetTaskEstimateAlertsParams{
+					ID:                 clonedTask.ID,
+					EstimateWarnedAt:   task.EstimateWarnedAt,
+					EstimateExceededAt: task.EstimateExceededAt,
+				})
+				if err != nil {
+					log.Println(err)
+				}
+			}
 
-		cfg.WSClientManager.BroadcastToSameUser(context.Background(), "tasks_refresher", client.User.ID, refresher{
-			Categories:  category,
-			KeyCommands: keyCommands,
-			Tasks:       tasks,
-		})
-	}
-}
+			// the clone stays in the original's workflow column
+			if task.StateID.Valid {
+				_, err = cfg.DB.SetTaskState(context.Background(), database.SetTaskStateParams{
+					ID:             clonedTask.ID,
+					StateID:        task.StateID,
+					LastModifiedAt: lastEpochMs,
+				})
+				if err != nil {
+					log.Println(err)
+				}
+			}
 
-func (cfg *config) WSOnTaskDuplicate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
-	start := time.Now()
-	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("task_duplicate").Observe(time.Since(start).Seconds())
-	}()
+			// and in its project, so the project's tracked time keeps adding up
+			if task.ProjectID.Valid {
+				_, err = cfg.DB.SetTaskProject(context.Background(), database.SetTaskProjectParams{
+					ID:             clonedTask.ID,
+					ProjectID:      task.ProjectID,
+					LastModifiedAt: lastEpochMs,
+				})
+				if err != nil {
+					log.Println(err)
+				}
+			}
 
-	type duplicateRequest struct {
-		TaskID uuid.UUID `json:"task_id"`
+			// the budget keeps counting the time tracked before the clone
+			if task.BudgetMinutes.Valid {
+				err = cfg.DB.CopyTaskBudget(context.Background(), database.CopyTaskBudgetParams{
+					ID:       clonedTask.ID,
+					SourceID: task.ID,
+				})
+				if err != nil {
+					log.Println(err)
+				}
+			}
+		}
 	}
 
-	var request struct {
-		Data duplicateRequest `json:"data"`
-	}
-	err := json.Unmarshal(data, &request)
+	err = cfg.DB.MarkUserRolledOver(context.Background(), database.MarkUserRolledOverParams{
+		ID:             user.ID,
+		LastRolloverAt: timeNow,
+	})
 	if err != nil {
-		return err
+		log.Println(err)
 	}
 
-	// Get the original task from database
-	// Note: We'll need to add a GetTaskByID query first
-	originalTask, err := cfg.DB.GetTaskByIDWithTiming(ctx, request.Data.TaskID)
+	if len(tasks) == 0 {
+		return
+	}
+
+	// emit a refresher to all connected devices of the user
+	tasks, err = cfg.DB.GetActiveTaskByUUIDWithTiming(context.Background(), database.GetActiveTaskByUUIDParams{UserID: user.ID})
+	if err != nil {
+		log.Println(err)
+	}
+
+	var category string
+	var keyCommands string
+
+	if user.Categories.Valid {
+		category = user.Categories.String
+	}
+	if user.KeyCommands.Valid {
+		keyCommands = user.KeyCommands.String
+	}
+
+	checklistItems, err := cfg.DB.GetActiveChecklistItemsByUser(context.Background(), user.ID)
+	if err != nil {
+		log.Println(err)
+	}
+
+	type refresher struct {
+		Categories     string                       `json:"categories"`
+		KeyCommands    string                       `json:"key_commands"`
+		Tasks          []database.Task              `json:"tasks"`
+		ChecklistItems []database.TaskChecklistItem `json:"checklist_items"`
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(context.Background(), "tasks_refresher", user.ID, refresher{
+		Categories:     category,
+		KeyCommands:    keyCommands,
+		Tasks:          tasks,
+		ChecklistItems: checklistItems,
+	})
+}
+
+// closeOutTaskDay is the 'continue' rollover: the day's time is stored as a segment
+// and the task itself keeps its ID with the timer reset for the new day.
+func (cfg *config) closeOutTaskDay(user database.User, task database.Task, durationStr string, timeNow time.Time) {
+	ctx := context.Background()
+	lastEpochMs := timeNow.UnixMilli()
+	dayStart, _ := userDayBounds(user, timeNow)
+
+	_, err := cfg.DB.CreateTaskSegment(ctx, database.CreateTaskSegmentParams{
+		ID:       uuid.New(),
+		TaskID:   task.ID,
+		UserID:   task.UserID,
+		DayStart: dayStart,
+		EndedAt:  timeNow.UTC(),
+		Duration: durationStr,
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	updatedTask, err := cfg.DB.ResetTaskDay(ctx, database.ResetTaskDayParams{
+		ID: task.ID,
+		ToggledAt: sql.NullInt64{
+			Int64: ternary(task.ToggledAt.Int64 == 0, 0, lastEpochMs),
+			Valid: ternary(task.ToggledAt.Int64 == 0, false, true),
+		},
+		LastModifiedAt: lastEpochMs,
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{}, "rollover", &task, updatedTask)
+}
+
+func (cfg *config) WSOnTaskDuplicate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_duplicate").Observe(time.Since(start).Seconds())
+	}()
+
+	type duplicateRequest struct {
+		TaskID uuid.UUID `json:"task_id"`
+	}
+
+	var request struct {
+		Data duplicateRequest `json:"data"`
+	}
+	err := json.Unmarshal(data, &request)
+	if err != nil {
+		return err
+	}
+
+	// Get the original task from database
+	// Note: We'll need to add a GetTaskByID query first
+	originalTask, err := cfg.DB.GetTaskByIDWithTiming(ctx, request.Data.TaskID)
 	if err != nil {
 		return err
 	}
@@ -958,12 +1563,65 @@ func (cfg *config) WSOnTaskDuplicate(ctx context.Context, c *websocket.Conn, SID
 		Priority:          originalTask.Priority,          // Copy
 		DueAt:             originalTask.DueAt,             // Copy
 		ShowBeforeDueTime: originalTask.ShowBeforeDueTime, // Copy
+		EstimateMinutes:   originalTask.EstimateMinutes,   // Copy
 	})
 
 	if err != nil {
 		return err
 	}
 
+	// The copy starts with a fresh checklist, every item unchecked
+	err = cfg.DB.CopyChecklistItems(ctx, database.CopyChecklistItemsParams{
+		TargetTaskID: duplicateTask.ID,
+		KeepChecked:  false,
+		SourceTaskID: originalTask.ID,
+	})
+	if err != nil {
+		return err
+	}
+	duplicateTask, err = cfg.DB.RefreshTaskChecklistCounts(ctx, duplicateTask.ID)
+	if err != nil {
+		return err
+	}
+
+	if originalTask.StateID.Valid {
+		duplicateTask, err = cfg.DB.SetTaskState(ctx, database.SetTaskStateParams{
+			ID:             duplicateTask.ID,
+			StateID:        originalTask.StateID,
+			LastModifiedAt: duplicateTask.LastModifiedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if originalTask.ProjectID.Valid {
+		duplicateTask, err = cfg.DB.SetTaskProject(ctx, database.SetTaskProjectParams{
+			ID:             duplicateTask.ID,
+			ProjectID:      originalTask.ProjectID,
+			LastModifiedAt: duplicateTask.LastModifiedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// A duplicate is new work, it gets the same budget with nothing used yet
+	if originalTask.BudgetMinutes.Valid {
+		duplicateTask, err = cfg.DB.SetTaskBudget(ctx, database.SetTaskBudgetParams{
+			ID:             duplicateTask.ID,
+			BudgetMinutes:  originalTask.BudgetMinutes,
+			BudgetPolicy:   originalTask.BudgetPolicy,
+			LastModifiedAt: duplicateTask.LastModifiedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "duplicate", &originalTask, duplicateTask)
+	planTaskDueJobs(ctx, cfg.DB, duplicateTask)
+
 	// Emit the new task via new_task_created event
 	cfg.WSClientManager.BroadcastToSameUser(
 		ctx,
@@ -1033,14 +1691,22 @@ func (cfg *config) WSOnTaskSplit(ctx context.Context, c *websocket.Conn, SID uui
 
 	queries := cfg.DB.WithTx(tx)
 
-	// Delete the original task
-	err = queries.DeleteTask(ctx, originalTask.ID)
+	// Dependency rows cascade with the original, so remember who was waiting on it
+	// and refresh them once the split tasks have taken its place
+	dependentIDs, err := queries.GetDependentTaskIDs(ctx, originalTask.ID)
+	if err != nil {
+		return err
+	}
+
+	// The split tasks get due reminders of their own once committed
+	err = queries.CancelPendingJobsForTask(ctx, uuid.NullUUID{UUID: originalTask.ID, Valid: true})
 	if err != nil {
 		return err
 	}
 
 	// Create split tasks
 	var splitTasks []database.Task
+	var revisions []pendingTaskRevision
 	lastEpochMs := time.Now().UnixMilli()
 
 	for _, split := range request.Data.Splits {
@@ -1072,21 +1738,120 @@ func (cfg *config) WSOnTaskSplit(ctx context.Context, c *websocket.Conn, SID uui
 			Priority:          originalTask.Priority,
 			DueAt:             originalTask.DueAt,
 			ShowBeforeDueTime: originalTask.ShowBeforeDueTime,
+			EstimateMinutes:   originalTask.EstimateMinutes,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if originalTask.StateID.Valid {
+			splitTask, err = queries.SetTaskState(ctx, database.SetTaskStateParams{
+				ID:             splitTask.ID,
+				StateID:        originalTask.StateID,
+				LastModifiedAt: lastEpochMs,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if originalTask.ProjectID.Valid {
+			splitTask, err = queries.SetTaskProject(ctx, database.SetTaskProjectParams{
+				ID:             splitTask.ID,
+				ProjectID:      originalTask.ProjectID,
+				LastModifiedAt: lastEpochMs,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Notes are a log of the work, so every split keeps the original's history
+		err = queries.CopyTaskNotes(ctx, database.CopyTaskNotesParams{
+			TargetTaskID: splitTask.ID,
+			SourceTaskID: originalTask.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Each split carries the whole checklist as it stood, checked items included
+		err = queries.CopyChecklistItems(ctx, database.CopyChecklistItemsParams{
+			TargetTaskID: splitTask.ID,
+			KeepChecked:  true,
+			SourceTaskID: originalTask.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		splitTask, err = queries.RefreshTaskChecklistCounts(ctx, splitTask.ID)
+		if err != nil {
+			return err
+		}
+
+		// Every split keeps the original's blockers and dependents
+		err = queries.CopyTaskDependencies(ctx, database.CopyTaskDependenciesParams{
+			OldTaskID: originalTask.ID,
+			NewTaskID: splitTask.ID,
 		})
+		if err != nil {
+			return err
+		}
 
+		splitTask, err = queries.RefreshTaskBlocked(ctx, splitTask.ID)
 		if err != nil {
 			return err
 		}
 
+		revisions = append(revisions, pendingTaskRevision{action: "split", before: originalTask, after: splitTask})
+
 		splitTasks = append(splitTasks, splitTask)
 	}
 
+	// Copies would count the corrections and the closed out days twice, so they move
+	// to the first split; the adjustments stay reversible there
+	if len(splitTasks) > 0 {
+		err = queries.MoveTimeAdjustments(ctx, database.MoveTimeAdjustmentsParams{
+			NewTaskID: splitTasks[0].ID,
+			OldTaskID: originalTask.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = queries.MoveTaskSegments(ctx, database.MoveTaskSegmentsParams{
+			NewTaskID: splitTasks[0].ID,
+			OldTaskID: originalTask.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete the original task once everything that cascades with it is copied
+	err = queries.DeleteTask(ctx, originalTask.ID)
+	if err != nil {
+		return err
+	}
+
+	// Close the original's timeline; the split tasks reference it through their id diff
+	revisions = append(revisions, pendingTaskRevision{action: "split", before: originalTask, after: originalTask})
+
 	// Commit transaction
 	err = tx.Commit()
 	if err != nil {
 		return err
 	}
 
+	recordTaskRevisions(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, revisions)
+
+	cfg.refreshDependentTasks(ctx, dependentIDs)
+	for _, splitTask := range splitTasks {
+		planTaskDueJobs(ctx, cfg.DB, splitTask)
+	}
+
 	// Emit events only if original task was not completed
 	if !originalTask.IsCompleted {
 		log.Printf("Emitting events for task split - original task ID: %s, splits: %d", originalTask.ID, len(splitTasks))
@@ -1117,13 +1882,14 @@ func (cfg *config) WSOnTaskSplit(ctx context.Context, c *websocket.Conn, SID uui
 		log.Printf("Task split completed but original task was already completed - not emitting events")
 	}
 
+	cfg.refreshGoalProgress(ctx, SID)
 	return nil
 }
 
-func (cfg *config) WSOnNotificationsFetch(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnTaskMerge(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("notifications_fetch").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("task_merge").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1131,120 +1897,350 @@ func (cfg *config) WSOnNotificationsFetch(ctx context.Context, c *websocket.Conn
 		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	type fetchRequest struct {
-		Offset            int32    `json:"offset"`
-		Limit             int32    `json:"limit"`
-		Statuses          []string `json:"statuses"`
-		NotificationTypes []string `json:"notification_types"`
-		Priorities        []string `json:"priorities"`
-		IncludeSnoozed    *bool    `json:"include_snoozed"`
-		ExpiredOnly       *bool    `json:"expired_only"`
+	type mergeRequest struct {
+		TaskIDs   []uuid.UUID `json:"task_ids"`
+		PrimaryID uuid.UUID   `json:"primary_id"`
 	}
 
-	var payload struct {
-		Data fetchRequest `json:"data"`
+	var request struct {
+		Data mergeRequest `json:"data"`
 	}
-
-	if err := json.Unmarshal(data, &payload); err != nil {
+	err := json.Unmarshal(data, &request)
+	if err != nil {
 		return err
 	}
 
-	const defaultPageSize int32 = 10
-	const maxPageSize int32 = 100
+	// Drop duplicate IDs so a task can't be merged into itself
+	seen := make(map[uuid.UUID]struct{})
+	var taskIDs []uuid.UUID
+	for _, id := range request.Data.TaskIDs {
+		if id == uuid.Nil {
+			return sendError(c, "invalid_request", "Invalid task ID format", 400)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		taskIDs = append(taskIDs, id)
+	}
 
-	offset := payload.Data.Offset
-	if offset < 0 {
-		offset = 0
+	if len(taskIDs) < 2 {
+		return sendError(c, "invalid_request", "At least two tasks are required to merge", 400)
 	}
 
-	limit := payload.Data.Limit
-	if limit <= 0 {
-		limit = defaultPageSize
+	// Default to the first task when no primary is given
+	primaryID := request.Data.PrimaryID
+	if primaryID == uuid.Nil {
+		primaryID = taskIDs[0]
 	}
-	if limit > maxPageSize {
-		limit = maxPageSize
+	if _, ok := seen[primaryID]; !ok {
+		return sendError(c, "invalid_request", "Primary task must be one of the merged tasks", 400)
 	}
 
-	params := database.ListNotificationsByUserParams{
-		UserID:            client.User.ID,
-		Statuses:          payload.Data.Statuses,
-		NotificationTypes: payload.Data.NotificationTypes,
-		Priorities:        payload.Data.Priorities,
-		OffsetVal:         sql.NullInt32{Int32: offset, Valid: true},
-		LimitVal:          sql.NullInt32{Int32: limit, Valid: true},
-	}
+	// Load and verify every task before touching anything
+	var primaryTask database.Task
+	var mergedTasks []database.Task
+	for _, id := range taskIDs {
+		task, err := cfg.DB.GetTaskByIDWithTiming(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return sendError(c, "not_found", "Task not found", 404)
+			}
+			return err
+		}
 
-	if payload.Data.IncludeSnoozed != nil {
-		params.IncludeSnoozed = sql.NullBool{Bool: *payload.Data.IncludeSnoozed, Valid: true}
-	}
-	if payload.Data.ExpiredOnly != nil {
-		params.ExpiredOnly = sql.NullBool{Bool: *payload.Data.ExpiredOnly, Valid: true}
-	}
+		if task.UserID != client.User.ID {
+			return sendError(c, "unauthorized", "Task does not belong to user", 403)
+		}
 
-	notifications, err := cfg.DB.ListNotificationsByUserWithTiming(ctx, params)
-	if err != nil {
-		return err
+		if id == primaryID {
+			primaryTask = task
+		} else {
+			mergedTasks = append(mergedTasks, task)
+		}
 	}
 
-	response := struct {
-		Notifications []database.Notification `json:"notifications"`
-		Offset        int32                   `json:"offset"`
-		Limit         int32                   `json:"limit"`
-		HasMore       bool                    `json:"has_more"`
-	}{
-		Notifications: notifications,
-		Offset:        offset,
-		Limit:         limit,
-		HasMore:       int32(len(notifications)) == limit,
+	for _, task := range mergedTasks {
+		if task.IsCompleted != primaryTask.IsCompleted {
+			return sendError(c, "invalid_request", "Cannot merge completed tasks with active ones", 400)
+		}
 	}
 
-	return cfg.WSClientManager.SendToClient(ctx, "notifications_batch", SID, response)
-}
+	// Sum the stored durations plus any running segment, keep the earliest creation time
+	lastEpochMs := time.Now().UnixMilli()
+	createdAt := primaryTask.CreatedAt
+	isActive := false
+	var totalDurationMs int64
 
-func (cfg *config) WSOnNotificationMarkSeen(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
-	start := time.Now()
-	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("notification_mark_seen").Observe(time.Since(start).Seconds())
-	}()
+	for _, task := range append([]database.Task{primaryTask}, mergedTasks...) {
+		durationMs, err := durationStrToInt(task.Duration)
+		if err != nil {
+			return err
+		}
+		totalDurationMs += durationMs
 
-	client, ok := cfg.getClientBySID(SID)
-	if !ok {
-		return fmt.Errorf("client not found for SID %s", SID)
-	}
+		if task.IsActive && task.ToggledAt.Valid && task.ToggledAt.Int64 != 0 {
+			isActive = true
+			if segmentMs := lastEpochMs - task.ToggledAt.Int64; segmentMs > 0 {
+				totalDurationMs += segmentMs
+			}
+		}
 
-	var payload struct {
-		Data struct {
-			NotificationIDs []uuid.UUID `json:"notification_ids"`
-		} `json:"data"`
+		if task.CreatedAt.Before(createdAt) {
+			createdAt = task.CreatedAt
+		}
 	}
 
-	if err := json.Unmarshal(data, &payload); err != nil {
+	duration, err := durationIntToStr(totalDurationMs / 1000)
+	if err != nil {
 		return err
 	}
 
-	if len(payload.Data.NotificationIDs) == 0 {
-		return nil
+	// A running merge restarts its segment now since the running time was folded into the duration
+	toggledAt := sql.NullInt64{Valid: false}
+	if isActive {
+		toggledAt = sql.NullInt64{
+			Int64: lastEpochMs,
+			Valid: true,
+		}
 	}
 
-	lastModified := time.Now().UnixMilli()
-	updates, err := cfg.DB.MarkNotificationsSeenWithTiming(ctx, database.MarkNotificationsSeenParams{
-		LastModifiedAt:  lastModified,
-		UserID:          client.User.ID,
-		NotificationIds: payload.Data.NotificationIDs,
-	})
+	// Start database transaction
+	tx, err := cfg.DBPool.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	cfg.broadcastNotificationSet(ctx, "notifications_marked_seen", client.User.ID, updates)
-	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
-	return nil
-}
+	queries := cfg.DB.WithTx(tx)
 
-func (cfg *config) WSOnNotificationMarkAllSeen(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	mergedTask, err := queries.MergeTask(ctx, database.MergeTaskParams{
+		ID:             primaryTask.ID,
+		CreatedAt:      createdAt,
+		Duration:       duration,
+		IsActive:       isActive,
+		ToggledAt:      toggledAt,
+		LastModifiedAt: lastEpochMs,
+	})
+	if err != nil {
+		return err
+	}
+
+	revisions := []pendingTaskRevision{{action: "merge", before: primaryTask, after: mergedTask}}
+
+	for _, task := range mergedTasks {
+		// Hand the absorbed task's dependencies over to the primary before it cascades away
+		err = queries.ReassignTaskDependencies(ctx, database.ReassignTaskDependenciesParams{
+			OldTaskID: task.ID,
+			NewTaskID: mergedTask.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Checklist items go to the end of the primary's checklist
+		err = queries.MoveChecklistItems(ctx, database.MoveChecklistItemsParams{
+			NewTaskID: mergedTask.ID,
+			OldTaskID: task.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Notes are a log of the work, so they follow it into the primary
+		err = queries.MoveTaskNotes(ctx, database.MoveTaskNotesParams{
+			NewTaskID: mergedTask.ID,
+			OldTaskID: task.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The absorbed duration carries its manual corrections, and they stay reversible
+		err = queries.MoveTimeAdjustments(ctx, database.MoveTimeAdjustmentsParams{
+			NewTaskID: mergedTask.ID,
+			OldTaskID: task.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Days a continue rollover closed out would cascade with the absorbed task
+		err = queries.MoveTaskSegments(ctx, database.MoveTaskSegmentsParams{
+			NewTaskID: mergedTask.ID,
+			OldTaskID: task.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Only the primary's due date survives the merge
+		err = queries.CancelPendingJobsForTask(ctx, uuid.NullUUID{UUID: task.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		err = queries.DeleteTask(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		// Close the absorbed task's own timeline, like the original of a split
+		revisions = append(revisions, pendingTaskRevision{action: "merge", before: task, after: task})
+	}
+
+	mergedTask, err = queries.RefreshTaskBlocked(ctx, mergedTask.ID)
+	if err != nil {
+		return err
+	}
+
+	mergedTask, err = queries.RefreshTaskChecklistCounts(ctx, mergedTask.ID)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	recordTaskRevisions(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, revisions)
+
+	// Emit events only if the merged tasks were not completed
+	if !mergedTask.IsCompleted {
+		log.Printf("Emitting events for task merge - primary task ID: %s, merged: %d", mergedTask.ID, len(mergedTasks))
+
+		for _, task := range mergedTasks {
+			cfg.WSClientManager.BroadcastToSameUser(
+				ctx,
+				"related_task_deleted",
+				client.User.ID,
+				struct {
+					ID uuid.UUID `json:"id"`
+				}{
+					ID: task.ID,
+				},
+			)
+		}
+
+		cfg.WSClientManager.BroadcastToSameUser(
+			ctx,
+			"related_task_edited",
+			client.User.ID,
+			mergedTask,
+		)
+	} else {
+		log.Printf("Task merge completed but tasks were already completed - not emitting events")
+	}
+
+	cfg.refreshGoalProgress(ctx, SID)
+	return nil
+}
+
+type taskFieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Fields that change on every write or are derived by the database; diffing them only adds noise.
+var taskRevisionSkippedFields = map[string]struct{}{
+	"last_modified_at": {},
+	"visible_from":     {},
+}
+
+func taskFieldMap(task database.Task) (map[string]interface{}, error) {
+	raw, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(raw, &fields)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffTaskFields compares the JSON representation of two task states. A nil
+// before means the task was just created, so every field is reported.
+func diffTaskFields(before *database.Task, after database.Task) (map[string]taskFieldChange, error) {
+	afterFields, err := taskFieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeFields := map[string]interface{}{}
+	if before != nil {
+		beforeFields, err = taskFieldMap(*before)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make(map[string]taskFieldChange)
+	for field, newValue := range afterFields {
+		if _, skip := taskRevisionSkippedFields[field]; skip {
+			continue
+		}
+
+		oldValue := beforeFields[field]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes[field] = taskFieldChange{
+			Old: oldValue,
+			New: newValue,
+		}
+	}
+
+	return changes, nil
+}
+
+// recordTaskRevision stores the field-level diff and a snapshot of the new state.
+// Failures are logged rather than returned so history never blocks the mutation itself.
+// It must not run on transaction-bound queries: in Postgres a failed insert aborts
+// the transaction, so mutations inside one queue a pendingTaskRevision instead.
+func recordTaskRevision(ctx context.Context, queries *database.Queries, actorSID uuid.NullUUID, action string, before *database.Task, after database.Task) {
+	changes, err := diffTaskFields(before, after)
+	if err != nil {
+		log.Printf("Failed to diff %s revision for task %s: %v", action, after.ID, err)
+		return
+	}
+
+	changesJSON, _ := json.Marshal(changes)
+	snapshotJSON, _ := json.Marshal(after)
+
+	_, err = queries.CreateTaskRevision(ctx, database.CreateTaskRevisionParams{
+		ID:       uuid.New(),
+		TaskID:   after.ID,
+		UserID:   after.UserID,
+		ActorSid: actorSID,
+		Action:   action,
+		Changes:  changesJSON,
+		Snapshot: snapshotJSON,
+	})
+	logDBError("Failed to record "+action+" revision for task "+after.ID.String(), err)
+}
+
+// pendingTaskRevision is a revision made inside a transaction, recorded once it commits
+type pendingTaskRevision struct {
+	action string
+	before database.Task
+	after  database.Task
+}
+
+func recordTaskRevisions(ctx context.Context, queries *database.Queries, actorSID uuid.NullUUID, revisions []pendingTaskRevision) {
+	for i := range revisions {
+		recordTaskRevision(ctx, queries, actorSID, revisions[i].action, &revisions[i].before, revisions[i].after)
+	}
+}
+
+func (cfg *config) WSOnTaskDefer(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("notification_mark_all_seen").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("task_defer").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1254,7 +2250,8 @@ func (cfg *config) WSOnNotificationMarkAllSeen(ctx context.Context, c *websocket
 
 	var payload struct {
 		Data struct {
-			NotificationIDs []uuid.UUID `json:"notification_ids"`
+			ID          uuid.UUID  `json:"id"`
+			HiddenUntil *time.Time `json:"hidden_until"`
 		} `json:"data"`
 	}
 
@@ -1262,38 +2259,56 @@ func (cfg *config) WSOnNotificationMarkAllSeen(ctx context.Context, c *websocket
 		return err
 	}
 
-	lastModified := time.Now().UnixMilli()
-	var (
-		updates []database.Notification
-		err     error
-	)
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Invalid task ID format", 400)
+	}
 
-	if len(payload.Data.NotificationIDs) > 0 {
-		updates, err = cfg.DB.MarkNotificationsSeenWithTiming(ctx, database.MarkNotificationsSeenParams{
-			LastModifiedAt:  lastModified,
-			UserID:          client.User.ID,
-			NotificationIds: payload.Data.NotificationIDs,
-		})
-	} else {
-		updates, err = cfg.DB.MarkAllNotificationsSeenWithTiming(ctx, database.MarkAllNotificationsSeenParams{
-			UserID:         client.User.ID,
-			LastModifiedAt: lastModified,
-		})
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+	if task.IsCompleted {
+		return sendError(c, "invalid_request", "Completed tasks cannot be deferred", 400)
 	}
 
+	// A null or past hidden_until brings the task back right away
+	hiddenUntil := sql.NullTime{}
+	if payload.Data.HiddenUntil != nil && payload.Data.HiddenUntil.After(time.Now()) {
+		if task.IsActive {
+			return sendError(c, "invalid_request", "Pause the task before deferring it", 400)
+		}
+		hiddenUntil = sql.NullTime{Time: payload.Data.HiddenUntil.UTC(), Valid: true}
+	}
+
+	updated, err := cfg.DB.SetTaskHiddenUntil(ctx, database.SetTaskHiddenUntilParams{
+		ID:             task.ID,
+		HiddenUntil:    hiddenUntil,
+		LastModifiedAt: time.Now().UnixMilli(),
+	})
 	if err != nil {
-		return err
+		logDBError("Failed to defer task", err)
+		return sendError(c, ErrorDatabaseError, "Failed to defer task", 500)
 	}
 
-	cfg.broadcastNotificationSet(ctx, "notifications_marked_seen", client.User.ID, updates)
-	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "defer", &task, updated)
+
+	if hiddenUntil.Valid {
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "task_deferred", client.User.ID, updated)
+		return nil
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "tasks_became_visible", client.User.ID, struct {
+		Tasks []database.Task `json:"tasks"`
+	}{
+		Tasks: []database.Task{updated},
+	})
 	return nil
 }
 
-func (cfg *config) WSOnNotificationArchive(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnTaskHistory(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("notification_archive").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("task_history").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1303,7 +2318,7 @@ func (cfg *config) WSOnNotificationArchive(ctx context.Context, c *websocket.Con
 
 	var payload struct {
 		Data struct {
-			NotificationID uuid.UUID `json:"notification_id"`
+			TaskID uuid.UUID `json:"task_id"`
 		} `json:"data"`
 	}
 
@@ -1311,32 +2326,31 @@ func (cfg *config) WSOnNotificationArchive(ctx context.Context, c *websocket.Con
 		return err
 	}
 
-	if payload.Data.NotificationID == uuid.Nil {
-		return fmt.Errorf("notification_id is required")
+	if payload.Data.TaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Invalid task ID format", 400)
 	}
 
-	lastModified := time.Now().UnixMilli()
-	notification, err := cfg.DB.ArchiveNotificationWithTiming(ctx, database.ArchiveNotificationParams{
-		ID:             payload.Data.NotificationID,
-		UserID:         client.User.ID,
-		LastModifiedAt: lastModified,
+	revisions, err := cfg.DB.ListTaskRevisionsWithTiming(ctx, database.ListTaskRevisionsParams{
+		TaskID: payload.Data.TaskID,
+		UserID: client.User.ID,
 	})
-	if err == sql.ErrNoRows {
-		return nil
-	}
 	if err != nil {
 		return err
 	}
 
-	cfg.broadcastSingleNotification(ctx, "notification_archived", client.User.ID, notification)
-	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
-	return nil
+	return cfg.WSClientManager.SendToClient(ctx, "task_history", SID, struct {
+		TaskID    uuid.UUID               `json:"task_id"`
+		Revisions []database.TaskRevision `json:"revisions"`
+	}{
+		TaskID:    payload.Data.TaskID,
+		Revisions: revisions,
+	})
 }
 
-func (cfg *config) WSOnNotificationSnooze(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnTaskRevert(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("notification_snooze").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("task_revert").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1346,10 +2360,8 @@ func (cfg *config) WSOnNotificationSnooze(ctx context.Context, c *websocket.Conn
 
 	var payload struct {
 		Data struct {
-			NotificationID uuid.UUID `json:"notification_id"`
-			SnoozeUntil    *int64    `json:"snooze_until"`   // epoch millis
-			SnoozeMinutes  *int64    `json:"snooze_minutes"` // minutes from now
-			SnoozeSeconds  *int64    `json:"snooze_seconds"` // seconds from now
+			TaskID     uuid.UUID `json:"task_id"`
+			RevisionID uuid.UUID `json:"revision_id"`
 		} `json:"data"`
 	}
 
@@ -1357,286 +2369,230 @@ func (cfg *config) WSOnNotificationSnooze(ctx context.Context, c *websocket.Conn
 		return err
 	}
 
-	if payload.Data.NotificationID == uuid.Nil {
-		return fmt.Errorf("notification_id is required")
+	if payload.Data.TaskID == uuid.Nil || payload.Data.RevisionID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID and revision ID are required", 400)
 	}
 
-	var snoozeUntil time.Time
-	now := time.Now()
-	switch {
-	case payload.Data.SnoozeUntil != nil:
-		snoozeUntil = time.UnixMilli(*payload.Data.SnoozeUntil)
-	case payload.Data.SnoozeMinutes != nil:
-		snoozeUntil = now.Add(time.Duration(*payload.Data.SnoozeMinutes) * time.Minute)
-	case payload.Data.SnoozeSeconds != nil:
-		snoozeUntil = now.Add(time.Duration(*payload.Data.SnoozeSeconds) * time.Second)
-	default:
-		snoozeUntil = now.Add(5 * time.Minute)
+	revision, err := cfg.DB.GetTaskRevisionByID(ctx, payload.Data.RevisionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Revision not found", 404)
+		}
+		return err
 	}
 
-	if snoozeUntil.Before(now.Add(5 * time.Second)) {
-		snoozeUntil = now.Add(5 * time.Minute)
+	if revision.UserID != client.User.ID || revision.TaskID != payload.Data.TaskID {
+		return sendError(c, "unauthorized", "Revision does not belong to task", 403)
 	}
 
-	lastModified := time.Now().UnixMilli()
-	notification, err := cfg.DB.SnoozeNotificationWithTiming(ctx, database.SnoozeNotificationParams{
-		ID:             payload.Data.NotificationID,
-		SnoozedUntil:   sql.NullTime{Time: snoozeUntil.UTC(), Valid: true},
-		UserID:         client.User.ID,
-		LastModifiedAt: lastModified,
-	})
-	if err == sql.ErrNoRows {
-		return nil
-	}
+	currentTask, err := cfg.DB.GetTaskByIDWithTiming(ctx, payload.Data.TaskID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Task not found", 404)
+		}
 		return err
 	}
 
-	cfg.broadcastSingleNotification(ctx, "notification_snoozed", client.User.ID, notification)
-	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
-	return nil
-}
-
-func (cfg *config) emitNotificationUnseenCount(ctx context.Context, userID uuid.UUID) {
-	count, err := cfg.DB.CountUnseenNotificationsWithTiming(ctx, userID)
-	if err != nil {
-		log.Println("failed to compute unseen notification count:", err)
-		return
+	var snapshot database.Task
+	if err := json.Unmarshal(revision.Snapshot, &snapshot); err != nil {
+		return err
 	}
 
-	cfg.WSClientManager.BroadcastToSameUser(ctx, "notifications_unseen_count", userID, struct {
-		Count int64 `json:"count"`
-	}{
-		Count: count,
+	// Only the user-editable fields are restored; timers and completion state stay as they are
+	task, err := cfg.DB.EditTaskWithTiming(ctx, database.EditTaskParams{
+		ID:                currentTask.ID,
+		Title:             snapshot.Title,
+		Description:       snapshot.Description,
+		Category:          snapshot.Category,
+		Tags:              snapshot.Tags,
+		LastModifiedAt:    time.Now().UnixMilli(),
+		Priority:          snapshot.Priority,
+		DueAt:             snapshot.DueAt,
+		ShowBeforeDueTime: snapshot.ShowBeforeDueTime,
+		EstimateMinutes:   snapshot.EstimateMinutes,
 	})
-}
+	if err != nil {
+		return err
+	}
 
-func (cfg *config) broadcastNotificationSet(ctx context.Context, event string, userID uuid.UUID, notifications []database.Notification) {
-	if len(notifications) == 0 {
-		return
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "revert", &currentTask, task)
+	if dueRemindersChanged(currentTask, task) {
+		planTaskDueJobs(ctx, cfg.DB, task)
 	}
 
-	cfg.WSClientManager.BroadcastToSameUser(ctx, event, userID, struct {
-		Notifications []database.Notification `json:"notifications"`
-	}{
-		Notifications: notifications,
-	})
-}
+	cfg.WSClientManager.BroadcastToSameUser(
+		ctx,
+		"related_task_edited",
+		client.User.ID,
+		task,
+	)
 
-func (cfg *config) broadcastSingleNotification(ctx context.Context, event string, userID uuid.UUID, notification database.Notification) {
-	cfg.WSClientManager.BroadcastToSameUser(ctx, event, userID, notification)
+	cfg.refreshGoalProgress(ctx, SID)
+	return nil
 }
 
-func (cfg *config) DispatchDueNotifications() {
-	log.Printf("DispatchDueNotifications cron job started at %s UTC", time.Now().UTC().Format(time.RFC3339))
-	ctx := context.Background()
-	lastModified := time.Now().UnixMilli()
+// sortPositionStep is the gap left between neighbouring tasks when appending or renumbering.
+const sortPositionStep = 1024
 
-	// Handle snoozed notifications
-	notifications, err := cfg.DB.ReleaseDueSnoozedNotificationsWithTiming(ctx, lastModified)
-	if err != nil {
-		log.Println("failed to release snoozed notifications:", err)
-		return
+// taskOrderedBefore follows the list order: sort_position, then created_at
+func taskOrderedBefore(a, b database.Task) bool {
+	if a.SortPosition != b.SortPosition {
+		return a.SortPosition < b.SortPosition
 	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
 
-	if len(notifications) > 0 {
-		userBuckets := make(map[uuid.UUID][]database.Notification)
-		for _, notification := range notifications {
-			userBuckets[notification.UserID] = append(userBuckets[notification.UserID], notification)
-		}
+func (cfg *config) WSOnTaskReorder(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_reorder").Observe(time.Since(start).Seconds())
+	}()
 
-		for userID, bucket := range userBuckets {
-			cfg.broadcastNotificationSet(ctx, "notifications_reemitted", userID, bucket)
-			cfg.emitNotificationUnseenCount(ctx, userID)
-		}
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	cfg.dispatchTaskVisibility(ctx)
-	// Disabled old notification system - now using new scheduler system
-	// cfg.dispatchTaskDueNotifications(ctx, lastModified)
-	log.Printf("DispatchDueNotifications cron job completed at %s UTC", time.Now().UTC().Format(time.RFC3339))
-}
+	var payload struct {
+		Data struct {
+			ID     uuid.UUID  `json:"id"`
+			PrevID *uuid.UUID `json:"prev_id"`
+			NextID *uuid.UUID `json:"next_id"`
+		} `json:"data"`
+	}
 
-func (cfg *config) dispatchTaskVisibility(ctx context.Context) {
-	log.Println("dispatchTaskVisibility: Starting task visibility check")
-	tasks, err := cfg.DB.GetTasksDueForVisibilityAllWithTiming(ctx)
-	if err != nil {
-		log.Printf("Failed to fetch tasks due for visibility: %v", err)
-		return
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
 	}
-	log.Printf("dispatchTaskVisibility: Found %d tasks due for visibility", len(tasks))
-	if len(tasks) == 0 {
-		log.Println("dispatchTaskVisibility: No tasks found, returning")
-		return
+
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Invalid task ID format", 400)
+	}
+	if payload.Data.PrevID == nil && payload.Data.NextID == nil {
+		return sendError(c, "invalid_request", "prev_id or next_id is required", 400)
+	}
+	if (payload.Data.PrevID != nil && *payload.Data.PrevID == payload.Data.ID) ||
+		(payload.Data.NextID != nil && *payload.Data.NextID == payload.Data.ID) {
+		return sendError(c, "invalid_request", "A task cannot be placed next to itself", 400)
 	}
 
-	buckets := make(map[uuid.UUID][]database.Task)
-	for _, task := range tasks {
-		buckets[task.UserID] = append(buckets[task.UserID], task)
+	ids := []uuid.UUID{payload.Data.ID}
+	if payload.Data.PrevID != nil {
+		ids = append(ids, *payload.Data.PrevID)
+	}
+	if payload.Data.NextID != nil {
+		ids = append(ids, *payload.Data.NextID)
 	}
 
-	for userID, bucket := range buckets {
-		cfg.WSClientManager.BroadcastToSameUser(ctx, "tasks_became_visible", userID, struct {
-			Tasks []database.Task `json:"tasks"`
-		}{
-			Tasks: bucket,
-		})
-		log.Printf("Broadcasted %d tasks becoming visible for user %s", len(bucket), userID)
+	loaded := make(map[uuid.UUID]database.Task, len(ids))
+	for _, id := range ids {
+		task, err := cfg.DB.GetTaskByIDWithTiming(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return sendError(c, "not_found", "Task not found", 404)
+			}
+			return err
+		}
+		if task.UserID != client.User.ID {
+			return sendError(c, "unauthorized", "Task does not belong to user", 403)
+		}
+		loaded[id] = task
 	}
-}
 
-type dueStage struct {
-	ID          string
-	Duration    time.Duration
-	Title       string
-	Description func(task database.Task) string
-	Priority    string
-}
+	// Checked before anything is renumbered, so a bad request leaves every position alone
+	if payload.Data.PrevID != nil && payload.Data.NextID != nil &&
+		!taskOrderedBefore(loaded[*payload.Data.PrevID], loaded[*payload.Data.NextID]) {
+		return sendError(c, "invalid_request", "prev_id must be ordered before next_id", 400)
+	}
 
-var taskDueStages = []dueStage{
-	{
-		ID:       "48h",
-		Duration: 48 * time.Hour,
-		Title:    "Task due in 48 hours",
-		Description: func(task database.Task) string {
-			return fmt.Sprintf("Your task '%s' is due in 48 hours.", task.Title)
-		},
-		Priority: "low",
-	},
-	{
-		ID:       "24h",
-		Duration: 24 * time.Hour,
-		Title:    "Task due in 24 hours",
-		Description: func(task database.Task) string {
-			return fmt.Sprintf("Your task '%s' is due in 24 hours.", task.Title)
-		},
-		Priority: "low",
-	},
-	{
-		ID:       "12h",
-		Duration: 12 * time.Hour,
-		Title:    "Task due in 12 hours",
-		Description: func(task database.Task) string {
-			return fmt.Sprintf("Your task '%s' is due in 12 hours.", task.Title)
-		},
-		Priority: "normal",
-	},
-	{
-		ID:       "6h",
-		Duration: 6 * time.Hour,
-		Title:    "Task due in 6 hours",
-		Description: func(task database.Task) string {
-			return fmt.Sprintf("Your task '%s' is due in 6 hours.", task.Title)
-		},
-		Priority: "normal",
-	},
-	{
-		ID:       "3h",
-		Duration: 3 * time.Hour,
-		Title:    "Task due in 3 hours",
-		Description: func(task database.Task) string {
-			return fmt.Sprintf("Your task '%s' is due in 3 hours.", task.Title)
-		},
-		Priority: "normal",
-	},
-	{
-		ID:       "1h",
-		Duration: time.Hour,
-		Title:    "Task due in 1 hour",
-		Description: func(task database.Task) string {
-			return fmt.Sprintf("Your task '%s' is due in 1 hour.", task.Title)
-		},
-		Priority: "high",
-	},
-}
+	// computePosition places the task between its new neighbours; ok is false when
+	// the gap between them is exhausted and the list has to be renumbered first.
+	computePosition := func() (position float64, ok bool, err error) {
+		var prev, next *database.Task
+		if payload.Data.PrevID != nil {
+			task, err := cfg.DB.GetTaskByIDWithTiming(ctx, *payload.Data.PrevID)
+			if err != nil {
+				return 0, false, err
+			}
+			prev = &task
+		}
+		if payload.Data.NextID != nil {
+			task, err := cfg.DB.GetTaskByIDWithTiming(ctx, *payload.Data.NextID)
+			if err != nil {
+				return 0, false, err
+			}
+			next = &task
+		}
 
-func determineDueStage(diff time.Duration) (dueStage, bool) {
-	const triggerWindow = time.Minute
-	for _, stage := range taskDueStages {
-		if diff <= stage.Duration && diff > stage.Duration-triggerWindow {
-			return stage, true
+		switch {
+		case prev != nil && next != nil:
+			position = prev.SortPosition + (next.SortPosition-prev.SortPosition)/2
+			return position, position > prev.SortPosition && position < next.SortPosition, nil
+		case prev != nil:
+			return prev.SortPosition + sortPositionStep, true, nil
+		default:
+			return next.SortPosition - sortPositionStep, true, nil
 		}
 	}
-	return dueStage{}, false
-}
 
-func (cfg *config) dispatchTaskDueNotifications(ctx context.Context, lastModified int64) {
-	tasks, err := cfg.DB.GetUpcomingTasksForNotificationsWithTiming(ctx)
+	position, ok, err := computePosition()
 	if err != nil {
-		log.Printf("Failed to fetch upcoming tasks for notifications: %v", err)
-		return
-	}
-	if len(tasks) == 0 {
-		return
+		return err
 	}
 
-	now := time.Now()
-	for _, task := range tasks {
-		if !task.DueAt.Valid {
-			continue
-		}
-		diff := task.DueAt.Time.Sub(now)
-		if diff <= 0 {
-			continue
-		}
-
-		stage, ok := determineDueStage(diff)
-		if !ok {
-			continue
+	renumbered := false
+	if !ok {
+		if err := cfg.DB.RenumberTaskSortPositions(ctx, client.User.ID); err != nil {
+			return err
 		}
+		renumbered = true
 
-		hasNotification, err := cfg.DB.HasNotificationForTaskStageWithTiming(ctx, task.UserID, "due_task", task.ID.String(), stage.ID)
+		position, ok, err = computePosition()
 		if err != nil {
-			log.Printf("Failed to check existing notification for task %s stage %s: %v", task.ID, stage.ID, err)
-			continue
+			return err
 		}
-		if hasNotification {
-			continue
+		if !ok {
+			return fmt.Errorf("no sort position between the neighbours of task %s after renumbering", payload.Data.ID)
 		}
+	}
 
-		payload := map[string]interface{}{
-			"task_id":        task.ID.String(),
-			"task_title":     task.Title,
-			"due_at":         task.DueAt.Time,
-			"stage":          stage.ID,
-			"due_in_seconds": int(diff.Seconds()),
-			"category":       task.Category,
-		}
-		payloadJSON, _ := json.Marshal(payload)
+	task, err := cfg.DB.SetTaskSortPosition(ctx, database.SetTaskSortPositionParams{
+		ID:             payload.Data.ID,
+		SortPosition:   position,
+		LastModifiedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
 
-		notification, err := cfg.DB.CreateNotificationWithTiming(ctx, database.CreateNotificationParams{
-			ID:               uuid.New(),
-			UserID:           task.UserID,
-			Title:            stage.Title,
-			Description:      sql.NullString{String: stage.Description(task), Valid: true},
-			Status:           "unseen",
-			NotificationType: "due_task",
-			Payload:          payloadJSON,
-			Priority:         stage.Priority,
-			ExpiresAt: sql.NullTime{
-				Time:  task.DueAt.Time.Add(24 * time.Hour),
-				Valid: true,
-			},
-			LastModifiedAt: lastModified,
-		})
+	if renumbered {
+		// every position changed, so all sessions (issuer included) get the full list
+		tasks, err := cfg.DB.GetActiveTaskByUUIDWithTiming(ctx, database.GetActiveTaskByUUIDParams{UserID: client.User.ID})
 		if err != nil {
-			log.Printf("Failed to create due notification for task %s stage %s: %v", task.ID, stage.ID, err)
-			continue
+			return err
 		}
-
-		cfg.broadcastSingleNotification(ctx, "notification_created", task.UserID, notification)
-		cfg.emitNotificationUnseenCount(ctx, task.UserID)
-
-		log.Printf("Created due notification stage %s for task '%s' (user %s)", stage.ID, task.Title, task.UserID)
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "tasks_reordered", client.User.ID, struct {
+			Tasks []database.Task `json:"tasks"`
+		}{
+			Tasks: tasks,
+		})
+		return nil
 	}
-}
 
-// Schedule Management WebSocket Event Handlers
+	cfg.WSClientManager.BroadcastToSameUserNoIssuer(
+		ctx,
+		"related_task_reordered",
+		client.User.ID,
+		SID,
+		task,
+	)
+	return nil
+}
 
-func (cfg *config) WSOnScheduleCreate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnTaskDependencyAdd(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("schedule_create").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("task_dependency_add").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1644,81 +2600,102 @@ func (cfg *config) WSOnScheduleCreate(ctx context.Context, c *websocket.Conn, SI
 		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	type scheduleCreateRequest struct {
-		Kind              string     `json:"kind"`
-		Title             string     `json:"title"`
-		Tz                string     `json:"tz"`
-		StartLocal        time.Time  `json:"start_local"`
-		Rrule             *string    `json:"rrule"`
-		UntilLocal        *time.Time `json:"until_local"`
-		ShowBeforeMinutes *int32     `json:"show_before_minutes"`
-		NotifyOffsetsMin  []int32    `json:"notify_offsets_min"`
-		MutedOffsetsMin   []int32    `json:"muted_offsets_min"`
-		Category          *string    `json:"category"`
-	}
-
 	var payload struct {
-		Data scheduleCreateRequest `json:"data"`
+		Data struct {
+			TaskID          uuid.UUID `json:"task_id"`
+			DependsOnTaskID uuid.UUID `json:"depends_on_task_id"`
+		} `json:"data"`
 	}
 
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return sendError(c, "invalid_data", "Invalid schedule data", 400)
+		return err
 	}
 
-	// Validate required fields
-	if payload.Data.Kind == "" || payload.Data.Title == "" || payload.Data.Tz == "" {
-		return sendError(c, "invalid_data", "Kind, title, and timezone are required", 400)
+	taskID := payload.Data.TaskID
+	dependsOnTaskID := payload.Data.DependsOnTaskID
+	if taskID == uuid.Nil || dependsOnTaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID and dependency task ID are required", 400)
+	}
+	if taskID == dependsOnTaskID {
+		return sendError(c, "invalid_request", "A task cannot depend on itself", 400)
 	}
 
-	// Validate kind
-	if payload.Data.Kind != "task" && payload.Data.Kind != "reminder" {
-		return sendError(c, "invalid_data", "Kind must be 'task' or 'reminder'", 400)
+	for _, id := range []uuid.UUID{taskID, dependsOnTaskID} {
+		task, err := cfg.DB.GetTaskByIDWithTiming(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return sendError(c, "not_found", "Task not found", 404)
+			}
+			return sendError(c, ErrorDatabaseError, "Failed to load task", 500)
+		}
+		if task.UserID != client.User.ID {
+			return sendError(c, "unauthorized", "Task does not belong to user", 403)
+		}
 	}
 
-	// Prepare parameters
-	var rrule sql.NullString
-	if payload.Data.Rrule != nil {
-		rrule = sql.NullString{String: *payload.Data.Rrule, Valid: true}
+	tx, err := cfg.DBPool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	var untilLocal sql.NullTime
-	if payload.Data.UntilLocal != nil {
-		untilLocal = sql.NullTime{Time: *payload.Data.UntilLocal, Valid: true}
+	queries := cfg.DB.WithTx(tx)
+
+	// Two concurrent adds could each pass the cycle check and close a loop together,
+	// so a user's dependency edits go one at a time
+	if err := queries.LockUserTaskDependencies(ctx, client.User.ID); err != nil {
+		logDBError("Failed to lock dependencies of user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to add dependency", 500)
 	}
 
-	var showBeforeMinutes sql.NullInt32
-	if payload.Data.ShowBeforeMinutes != nil {
-		showBeforeMinutes = sql.NullInt32{Int32: *payload.Data.ShowBeforeMinutes, Valid: true}
+	// Adding task -> dependsOn would close a loop if dependsOn already (transitively) waits on task
+	cycle, err := queries.HasDependencyPath(ctx, database.HasDependencyPathParams{
+		FromTaskID: dependsOnTaskID,
+		ToTaskID:   taskID,
+	})
+	if err != nil {
+		logDBError("Failed to check dependency cycle", err)
+		return sendError(c, ErrorDatabaseError, "Failed to check dependencies", 500)
+	}
+	if cycle {
+		return sendError(c, "invalid_request", "Dependency would create a cycle", 400)
 	}
 
-	// Create schedule
-	schedule, err := cfg.DB.CreateSchedule(ctx, database.CreateScheduleParams{
-		UserID:     client.User.ID,
-		Kind:       payload.Data.Kind,
-		Title:      payload.Data.Title,
-		Tz:         payload.Data.Tz,
-		StartLocal: payload.Data.StartLocal,
-		Rrule:      rrule,
-		UntilLocal: untilLocal,
-		Column8:    showBeforeMinutes,
-		Column9:    payload.Data.NotifyOffsetsMin,
-		Column10:   payload.Data.MutedOffsetsMin,
-		Column11:   payload.Data.Category,
+	err = queries.AddTaskDependency(ctx, database.AddTaskDependencyParams{
+		TaskID:          taskID,
+		DependsOnTaskID: dependsOnTaskID,
+		UserID:          client.User.ID,
 	})
 	if err != nil {
-		log.Printf("Failed to create schedule: %v", err)
-		return sendError(c, ErrorDatabaseError, "Failed to create schedule", 500)
+		logDBError("Failed to add task dependency", err)
+		return sendError(c, ErrorDatabaseError, "Failed to add dependency", 500)
 	}
 
-	// Send success response
-	cfg.WSClientManager.SendToClient(ctx, "schedule_created", SID, schedule)
+	task, err := queries.RefreshTaskBlocked(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logDBError("Failed to add task dependency", err)
+		return sendError(c, ErrorDatabaseError, "Failed to add dependency", 500)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "task_dependency_added", client.User.ID, struct {
+		TaskID          uuid.UUID `json:"task_id"`
+		DependsOnTaskID uuid.UUID `json:"depends_on_task_id"`
+	}{
+		TaskID:          taskID,
+		DependsOnTaskID: dependsOnTaskID,
+	})
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
 	return nil
 }
 
-func (cfg *config) WSOnScheduleEdit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnTaskDependencyRemove(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("schedule_edit").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("task_dependency_remove").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1726,92 +2703,172 @@ func (cfg *config) WSOnScheduleEdit(ctx context.Context, c *websocket.Conn, SID
 		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	type scheduleEditRequest struct {
-		ID                uuid.UUID  `json:"id"`
-		Title             string     `json:"title"`
-		Tz                string     `json:"tz"`
-		StartLocal        time.Time  `json:"start_local"`
-		Rrule             *string    `json:"rrule"`
-		UntilLocal        *time.Time `json:"until_local"`
-		ShowBeforeMinutes *int32     `json:"show_before_minutes"`
-		NotifyOffsetsMin  []int32    `json:"notify_offsets_min"`
-		MutedOffsetsMin   []int32    `json:"muted_offsets_min"`
-		Category          *string    `json:"category"`
-	}
-
 	var payload struct {
-		Data scheduleEditRequest `json:"data"`
+		Data struct {
+			TaskID          uuid.UUID `json:"task_id"`
+			DependsOnTaskID uuid.UUID `json:"depends_on_task_id"`
+		} `json:"data"`
 	}
 
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return sendError(c, "invalid_data", "Invalid schedule data", 400)
+		return err
 	}
 
-	// Validate schedule ID
-	if payload.Data.ID == uuid.Nil {
-		return sendError(c, "invalid_data", "Schedule ID is required", 400)
+	taskID := payload.Data.TaskID
+	dependsOnTaskID := payload.Data.DependsOnTaskID
+	if taskID == uuid.Nil || dependsOnTaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID and dependency task ID are required", 400)
 	}
 
-	// Check if schedule exists and belongs to user
-	existingSchedule, err := cfg.DB.GetScheduleByID(ctx, payload.Data.ID)
+	task, err := cfg.DB.GetTaskByIDWithTiming(ctx, taskID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return sendError(c, "not_found", "Schedule not found", 404)
+			return sendError(c, "not_found", "Task not found", 404)
 		}
-		return sendError(c, ErrorDatabaseError, "Database error", 500)
+		return sendError(c, ErrorDatabaseError, "Failed to load task", 500)
 	}
-
-	if existingSchedule.UserID != client.User.ID {
-		return sendError(c, "unauthorized", "Schedule does not belong to user", 403)
+	if task.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Task does not belong to user", 403)
 	}
 
-	// Cancel future jobs and delete future occurrences
-	if err := cfg.DB.CancelFutureJobsForSchedule(ctx, uuid.NullUUID{UUID: payload.Data.ID, Valid: true}); err != nil {
-		log.Printf("Failed to cancel future jobs: %v", err)
+	err = cfg.DB.RemoveTaskDependency(ctx, database.RemoveTaskDependencyParams{
+		TaskID:          taskID,
+		DependsOnTaskID: dependsOnTaskID,
+	})
+	if err != nil {
+		logDBError("Failed to remove task dependency", err)
+		return sendError(c, ErrorDatabaseError, "Failed to remove dependency", 500)
 	}
 
-	if err := cfg.DB.DeleteFutureOccurrencesForSchedule(ctx, payload.Data.ID); err != nil {
-		log.Printf("Failed to delete future occurrences: %v", err)
+	task, err = cfg.DB.RefreshTaskBlocked(ctx, taskID)
+	if err != nil {
+		return err
 	}
 
-	// Increment revision
-	if err := cfg.DB.IncrementScheduleRev(ctx, payload.Data.ID); err != nil {
-		log.Printf("Failed to increment schedule revision: %v", err)
-	}
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "task_dependency_removed", client.User.ID, struct {
+		TaskID          uuid.UUID `json:"task_id"`
+		DependsOnTaskID uuid.UUID `json:"depends_on_task_id"`
+	}{
+		TaskID:          taskID,
+		DependsOnTaskID: dependsOnTaskID,
+	})
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	return nil
+}
 
-	// Prepare category
-	var category sql.NullString
-	if payload.Data.Category != nil {
-		category = sql.NullString{String: *payload.Data.Category, Valid: true}
+// releaseDependentTasks clears the blocked flag on tasks whose last open blocker
+// was the given task and notifies the owner about each of them.
+func (cfg *config) releaseDependentTasks(ctx context.Context, blockerID uuid.UUID) {
+	unblocked, err := cfg.DB.UnblockDependentTasks(ctx, blockerID)
+	if err != nil {
+		logDBError("Failed to unblock dependent tasks of "+blockerID.String(), err)
+		return
 	}
 
-	// Update schedule
-	schedule, err := cfg.DB.UpdateSchedule(ctx, database.UpdateScheduleParams{
-		ID:                payload.Data.ID,
-		Title:             payload.Data.Title,
-		Tz:                payload.Data.Tz,
-		StartLocal:        payload.Data.StartLocal,
-		Rrule:             sql.NullString{String: *payload.Data.Rrule, Valid: payload.Data.Rrule != nil},
-		UntilLocal:        sql.NullTime{Time: *payload.Data.UntilLocal, Valid: payload.Data.UntilLocal != nil},
-		ShowBeforeMinutes: sql.NullInt32{Int32: *payload.Data.ShowBeforeMinutes, Valid: payload.Data.ShowBeforeMinutes != nil},
-		NotifyOffsetsMin:  payload.Data.NotifyOffsetsMin,
-		MutedOffsetsMin:   payload.Data.MutedOffsetsMin,
-		Category:          category,
-	})
+	for _, task := range unblocked {
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", task.UserID, task)
+
+		if cfg.DispatcherService == nil {
+			continue
+		}
+
+		payload, err := json.Marshal(map[string]interface{}{
+			"kind":       "task_unblocked",
+			"task_id":    task.ID,
+			"blocker_id": blockerID,
+			"title":      task.Title,
+		})
+		if err != nil {
+			log.Println("Failed to marshal task_unblocked payload:", err)
+			continue
+		}
+
+		_, err = cfg.DispatcherService.Notify(ctx, database.CreateNotificationParams{
+			ID:               uuid.New(),
+			UserID:           task.UserID,
+			Title:            "Task Unblocked",
+			Description:      sql.NullString{String: "'" + task.Title + "' is ready to start.", Valid: true},
+			Status:           "unseen",
+			NotificationType: "task_unblocked",
+			Payload:          payload,
+			Priority:         "normal",
+			ExpiresAt:        sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true},
+			LastModifiedAt:   time.Now().UnixMilli(),
+		})
+		if err != nil {
+			log.Printf("Failed to create task_unblocked notification for task %s: %v", task.ID, err)
+			continue
+		}
+		cfg.emitNotificationUnseenCount(ctx, task.UserID)
+	}
+}
+
+// refreshDependentTasks recomputes the blocked flag for the given tasks after
+// one of their blockers went away.
+func (cfg *config) refreshDependentTasks(ctx context.Context, taskIDs []uuid.UUID) {
+	for _, id := range taskIDs {
+		task, err := cfg.DB.RefreshTaskBlocked(ctx, id)
+		if err != nil {
+			logDBError("Failed to refresh blocked flag for task "+id.String(), err)
+			continue
+		}
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", task.UserID, task)
+	}
+}
+
+// loadOwnedTask fetches a task and reports to the client when it is missing or
+// belongs to someone else, in which case ok is false
+func (cfg *config) loadOwnedTask(ctx context.Context, c *websocket.Conn, userID, taskID uuid.UUID) (task database.Task, ok bool, err error) {
+	task, err = cfg.DB.GetTaskByIDWithTiming(ctx, taskID)
 	if err != nil {
-		log.Printf("Failed to update schedule: %v", err)
-		return sendError(c, ErrorDatabaseError, "Failed to update schedule", 500)
+		if err == sql.ErrNoRows {
+			return task, false, sendError(c, "not_found", "Task not found", 404)
+		}
+		logDBError("Failed to load task "+taskID.String(), err)
+		return task, false, sendError(c, ErrorDatabaseError, "Failed to load task", 500)
+	}
+	if task.UserID != userID {
+		return task, false, sendError(c, "unauthorized", "Task does not belong to user", 403)
 	}
+	return task, true, nil
+}
 
-	// Send success response
-	cfg.WSClientManager.SendToClient(ctx, "schedule_updated", SID, schedule)
+// loadOwnedChecklistItem is loadOwnedTask for checklist items
+func (cfg *config) loadOwnedChecklistItem(ctx context.Context, c *websocket.Conn, userID, itemID uuid.UUID) (item database.TaskChecklistItem, ok bool, err error) {
+	item, err = cfg.DB.GetChecklistItemByID(ctx, itemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return item, false, sendError(c, "not_found", "Checklist item not found", 404)
+		}
+		logDBError("Failed to load checklist item "+itemID.String(), err)
+		return item, false, sendError(c, ErrorDatabaseError, "Failed to load checklist item", 500)
+	}
+	if item.UserID != userID {
+		return item, false, sendError(c, "unauthorized", "Checklist item does not belong to user", 403)
+	}
+	return item, true, nil
+}
+
+// emitChecklistChange sends the checklist event to the user's sessions and, when the
+// x/y progress may have moved, the task with its refreshed counts
+func (cfg *config) emitChecklistChange(ctx context.Context, userID uuid.UUID, event string, data interface{}, taskID uuid.UUID, countsChanged bool) error {
+	cfg.WSClientManager.BroadcastToSameUser(ctx, event, userID, data)
+	if !countsChanged {
+		return nil
+	}
+
+	task, err := cfg.DB.RefreshTaskChecklistCounts(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", userID, task)
 	return nil
 }
 
-func (cfg *config) WSOnScheduleDelete(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnChecklistItemAdd(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("schedule_delete").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues("checklist_item_add").Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1819,65 +2876,110 @@ func (cfg *config) WSOnScheduleDelete(ctx context.Context, c *websocket.Conn, SI
 		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	type scheduleDeleteRequest struct {
-		ID uuid.UUID `json:"id"`
-	}
-
 	var payload struct {
-		Data scheduleDeleteRequest `json:"data"`
+		Data struct {
+			ID     uuid.UUID `json:"id"`
+			TaskID uuid.UUID `json:"task_id"`
+			Text   string    `json:"text"`
+		} `json:"data"`
 	}
 
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return sendError(c, "invalid_data", "Invalid schedule data", 400)
+		return err
 	}
 
-	// Validate schedule ID
-	if payload.Data.ID == uuid.Nil {
-		return sendError(c, "invalid_data", "Schedule ID is required", 400)
+	text := strings.TrimSpace(payload.Data.Text)
+	if payload.Data.TaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID is required", 400)
+	}
+	if text == "" {
+		return sendError(c, "invalid_request", "Checklist item text is required", 400)
 	}
 
-	// Check if schedule exists and belongs to user
-	existingSchedule, err := cfg.DB.GetScheduleByID(ctx, payload.Data.ID)
+	_, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	// Clients may pick the ID so they can show the item before the echo arrives
+	id := payload.Data.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	item, err := cfg.DB.CreateChecklistItem(ctx, database.CreateChecklistItemParams{
+		ID:     id,
+		TaskID: payload.Data.TaskID,
+		UserID: client.User.ID,
+		Text:   text,
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return sendError(c, "not_found", "Schedule not found", 404)
-		}
-		return sendError(c, ErrorDatabaseError, "Database error", 500)
+		logDBError("Failed to create checklist item", err)
+		return sendError(c, ErrorDatabaseError, "Failed to add checklist item", 500)
 	}
 
-	if existingSchedule.UserID != client.User.ID {
-		return sendError(c, "unauthorized", "Schedule does not belong to user", 403)
+	return cfg.emitChecklistChange(ctx, client.User.ID, "checklist_item_added", item, item.TaskID, true)
+}
+
+func (cfg *config) WSOnChecklistItemEdit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("checklist_item_edit").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	// Cancel future jobs
-	if err := cfg.DB.CancelFutureJobsForSchedule(ctx, uuid.NullUUID{UUID: payload.Data.ID, Valid: true}); err != nil {
-		log.Printf("Failed to cancel future jobs: %v", err)
+	var payload struct {
+		Data struct {
+			ID   uuid.UUID `json:"id"`
+			Text string    `json:"text"`
+		} `json:"data"`
 	}
 
-	// Delete future occurrences (this will cascade to notification jobs and task_links)
-	if err := cfg.DB.DeleteFutureOccurrencesForSchedule(ctx, payload.Data.ID); err != nil {
-		log.Printf("Failed to delete future occurrences: %v", err)
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
 	}
 
-	// Actually delete the schedule (this will cascade to all occurrences, notification jobs, and task_links)
-	if err := cfg.DB.DeleteSchedule(ctx, payload.Data.ID); err != nil {
-		log.Printf("Failed to delete schedule: %v", err)
-		return sendError(c, ErrorDatabaseError, "Failed to delete schedule", 500)
+	text := strings.TrimSpace(payload.Data.Text)
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Checklist item ID is required", 400)
+	}
+	if text == "" {
+		return sendError(c, "invalid_request", "Checklist item text is required", 400)
 	}
 
-	// Send success response
-	cfg.WSClientManager.SendToClient(ctx, "schedule_deleted", SID, struct {
-		ID uuid.UUID `json:"id"`
-	}{
-		ID: payload.Data.ID,
+	_, ok, err := cfg.loadOwnedChecklistItem(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+
+	item, err := cfg.DB.UpdateChecklistItemText(ctx, database.UpdateChecklistItemTextParams{
+		ID:   payload.Data.ID,
+		Text: text,
 	})
-	return nil
+	if err != nil {
+		logDBError("Failed to edit checklist item", err)
+		return sendError(c, ErrorDatabaseError, "Failed to edit checklist item", 500)
+	}
+
+	return cfg.emitChecklistChange(ctx, client.User.ID, "checklist_item_edited", item, item.TaskID, false)
 }
 
-func (cfg *config) WSOnScheduleList(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+func (cfg *config) WSOnChecklistItemCheck(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	return cfg.setChecklistItemChecked(ctx, c, SID, data, "checklist_item_check", true)
+}
+
+func (cfg *config) WSOnChecklistItemUncheck(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	return cfg.setChecklistItemChecked(ctx, c, SID, data, "checklist_item_uncheck", false)
+}
+
+func (cfg *config) setChecklistItemChecked(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte, event string, checked bool) error {
 	start := time.Now()
 	defer func() {
-		metrics.WebSocketEventDuration.WithLabelValues("schedule_list").Observe(time.Since(start).Seconds())
+		metrics.WebSocketEventDuration.WithLabelValues(event).Observe(time.Since(start).Seconds())
 	}()
 
 	client, ok := cfg.getClientBySID(SID)
@@ -1885,113 +2987,1407 @@ func (cfg *config) WSOnScheduleList(ctx context.Context, c *websocket.Conn, SID
 		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	// Get user's schedules
-	schedules, err := cfg.DB.GetSchedulesByUser(ctx, client.User.ID)
-	if err != nil {
-		log.Printf("Failed to get schedules for user: %v", err)
-		return sendError(c, ErrorDatabaseError, "Failed to get schedules", 500)
+	var payload struct {
+		Data struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"data"`
 	}
 
-	// Send schedules list
-	cfg.WSClientManager.SendToClient(ctx, "schedules_list", SID, struct {
-		Schedules []database.Schedule `json:"schedules"`
-	}{
-		Schedules: schedules,
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Checklist item ID is required", 400)
+	}
+
+	item, ok, err := cfg.loadOwnedChecklistItem(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+	if item.Checked == checked {
+		// Nothing to do, but echo the item so a stale device catches up
+		cfg.WSClientManager.SendToClient(ctx, "checklist_item_edited", SID, item)
+		return nil
+	}
+
+	item, err = cfg.DB.SetChecklistItemChecked(ctx, database.SetChecklistItemCheckedParams{
+		ID:      payload.Data.ID,
+		Checked: checked,
 	})
-	return nil
+	if err != nil {
+		logDBError("Failed to update checklist item", err)
+		return sendError(c, ErrorDatabaseError, "Failed to update checklist item", 500)
+	}
+
+	return cfg.emitChecklistChange(ctx, client.User.ID, "checklist_item_edited", item, item.TaskID, true)
 }
 
-func (cfg *config) WSOnReminderSubmit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
-	// Get client
-	client, exists := cfg.WSClientManager.clients[SID]
-	if !exists {
-		return fmt.Errorf("client not found")
+// checklistItemOrderedBefore follows the checklist order: position, then created_at
+func checklistItemOrderedBefore(a, b database.TaskChecklistItem) bool {
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+func (cfg *config) WSOnChecklistItemReorder(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("checklist_item_reorder").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
 	}
 
-	// Parse payload
 	var payload struct {
-		Event string `json:"event"`
-		Data  struct {
-			Title         string `json:"title"`
-			Kind          string `json:"kind"`
-			ScheduleInput string `json:"scheduleInput"`
-			Schedule      struct {
-				Instant *struct {
-					Label string `json:"label"`
-					ISO   string `json:"iso"`
-				} `json:"instant"`
-				Recurrence *struct {
-					Text  string   `json:"text"`
-					Rule  string   `json:"rule"`
-					Start string   `json:"start"`
-					Next  []string `json:"next"`
-				} `json:"recurrence"`
-			} `json:"schedule"`
-			ShowBeforeMinutes *int32  `json:"show_before_minutes"`
-			Category          *string `json:"category"`
+		Data struct {
+			ID     uuid.UUID  `json:"id"`
+			PrevID *uuid.UUID `json:"prev_id"`
+			NextID *uuid.UUID `json:"next_id"`
 		} `json:"data"`
 	}
 
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return fmt.Errorf("failed to unmarshal payload: %v", err)
+		return err
 	}
 
-	// Validate required fields
-	if payload.Data.Title == "" {
-		return fmt.Errorf("title is required")
-	}
-	if payload.Data.Kind != "task" && payload.Data.Kind != "reminder" {
-		return fmt.Errorf("kind must be 'task' or 'reminder'")
-	}
-	if payload.Data.Schedule.Instant == nil && payload.Data.Schedule.Recurrence == nil {
-		return fmt.Errorf("either instant or recurrence must be provided")
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Checklist item ID is required", 400)
 	}
-	if payload.Data.ShowBeforeMinutes == nil {
-		return fmt.Errorf("show_before_minutes is required")
+	if payload.Data.PrevID == nil && payload.Data.NextID == nil {
+		return sendError(c, "invalid_request", "prev_id or next_id is required", 400)
 	}
 
-	// Determine timezone (use client's timezone or default to UTC)
-	tz := "UTC" // You might want to get this from client data or user preferences
+	if (payload.Data.PrevID != nil && *payload.Data.PrevID == payload.Data.ID) ||
+		(payload.Data.NextID != nil && *payload.Data.NextID == payload.Data.ID) {
+		return sendError(c, "invalid_request", "A checklist item cannot be placed next to itself", 400)
+	}
 
-	var startLocal time.Time
-	var rrule sql.NullString
-	var untilLocal sql.NullTime
+	item, ok, err := cfg.loadOwnedChecklistItem(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
 
-	if payload.Data.Schedule.Instant != nil {
-		// One-off schedule
-		startTime, err := time.Parse(time.RFC3339, payload.Data.Schedule.Instant.ISO)
-		if err != nil {
-			return fmt.Errorf("invalid instant ISO format: %v", err)
+	// Checked before anything is renumbered, so a bad request leaves every position alone
+	var neighbours []database.TaskChecklistItem
+	for _, id := range []*uuid.UUID{payload.Data.PrevID, payload.Data.NextID} {
+		if id == nil {
+			continue
 		}
-		startLocal = startTime
-		// rrule remains NULL for one-off
-	} else if payload.Data.Schedule.Recurrence != nil {
-		// Recurring schedule
-		startTime, err := time.Parse(time.RFC3339, payload.Data.Schedule.Recurrence.Start)
-		if err != nil {
-			return fmt.Errorf("invalid recurrence start format: %v", err)
+		neighbour, err := cfg.DB.GetChecklistItemByID(ctx, *id)
+		if err != nil && err != sql.ErrNoRows {
+			return err
 		}
-		startLocal = startTime
-		rrule = sql.NullString{String: payload.Data.Schedule.Recurrence.Rule, Valid: true}
-		// untilLocal remains NULL (no end date specified)
+		if err == sql.ErrNoRows || neighbour.TaskID != item.TaskID {
+			return sendError(c, "not_found", "Neighbouring checklist item not found on this task", 404)
+		}
+		neighbours = append(neighbours, neighbour)
+	}
+	if len(neighbours) == 2 && !checklistItemOrderedBefore(neighbours[0], neighbours[1]) {
+		return sendError(c, "invalid_request", "prev_id must be ordered before next_id", 400)
 	}
 
-	// Create schedule
-	schedule, err := cfg.DB.CreateSchedule(ctx, database.CreateScheduleParams{
-		UserID:     client.User.ID,
-		Kind:       payload.Data.Kind,
-		Title:      payload.Data.Title,
-		Tz:         tz,
-		StartLocal: startLocal,
-		Rrule:      rrule,
-		UntilLocal: untilLocal,
-		Column8:    payload.Data.ShowBeforeMinutes, // show_before_minutes
-		Column9:    nil,                            // notify_offsets_min - will use default '{2880,1440,720,360,180}'
-		Column10:   nil,                            // muted_offsets_min - will use default '{}'
-		Column11:   payload.Data.Category,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create schedule: %v", err)
+	// computePosition mirrors task_reorder: midpoint between the neighbours, ok is
+	// false when the gap is exhausted and the checklist has to be renumbered first.
+	// Neighbours must sit on the same task.
+	computePosition := func() (position float64, ok bool, err error) {
+		var prev, next *database.TaskChecklistItem
+		if payload.Data.PrevID != nil {
+			neighbour, err := cfg.DB.GetChecklistItemByID(ctx, *payload.Data.PrevID)
+			if err != nil {
+				return 0, false, err
+			}
+			if neighbour.TaskID != item.TaskID {
+				return 0, false, sql.ErrNoRows
+			}
+			prev = &neighbour
+		}
+		if payload.Data.NextID != nil {
+			neighbour, err := cfg.DB.GetChecklistItemByID(ctx, *payload.Data.NextID)
+			if err != nil {
+				return 0, false, err
+			}
+			if neighbour.TaskID != item.TaskID {
+				return 0, false, sql.ErrNoRows
+			}
+			next = &neighbour
+		}
+
+		switch {
+		case prev != nil && next != nil:
+			position = prev.Position + (next.Position-prev.Position)/2
+			return position, position > prev.Position && position < next.Position, nil
+		case prev != nil:
+			return prev.Position + sortPositionStep, true, nil
+		default:
+			return next.Position - sortPositionStep, true, nil
+		}
+	}
+
+	position, ok, err := computePosition()
+	if err == sql.ErrNoRows {
+		return sendError(c, "not_found", "Neighbouring checklist item not found on this task", 404)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		if err := cfg.DB.RenumberChecklistItems(ctx, item.TaskID); err != nil {
+			return err
+		}
+
+		position, ok, err = computePosition()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("no position between the neighbours of checklist item %s after renumbering", item.ID)
+		}
+
+		if _, err := cfg.DB.SetChecklistItemPosition(ctx, database.SetChecklistItemPositionParams{
+			ID:       item.ID,
+			Position: position,
+		}); err != nil {
+			return err
+		}
+
+		// every position changed, so all sessions get the task's full checklist
+		items, err := cfg.DB.GetChecklistItemsByTask(ctx, item.TaskID)
+		if err != nil {
+			return err
+		}
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "checklist_items_reordered", client.User.ID, struct {
+			TaskID uuid.UUID                    `json:"task_id"`
+			Items  []database.TaskChecklistItem `json:"items"`
+		}{
+			TaskID: item.TaskID,
+			Items:  items,
+		})
+		return nil
+	}
+
+	item, err = cfg.DB.SetChecklistItemPosition(ctx, database.SetChecklistItemPositionParams{
+		ID:       item.ID,
+		Position: position,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cfg.emitChecklistChange(ctx, client.User.ID, "checklist_item_edited", item, item.TaskID, false)
+}
+
+func (cfg *config) WSOnChecklistItemDelete(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("checklist_item_delete").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Checklist item ID is required", 400)
+	}
+
+	item, ok, err := cfg.loadOwnedChecklistItem(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+
+	if err := cfg.DB.DeleteChecklistItem(ctx, item.ID); err != nil {
+		logDBError("Failed to delete checklist item", err)
+		return sendError(c, ErrorDatabaseError, "Failed to delete checklist item", 500)
+	}
+
+	return cfg.emitChecklistChange(ctx, client.User.ID, "checklist_item_deleted", struct {
+		ID     uuid.UUID `json:"id"`
+		TaskID uuid.UUID `json:"task_id"`
+	}{
+		ID:     item.ID,
+		TaskID: item.TaskID,
+	}, item.TaskID, true)
+}
+
+func (cfg *config) WSOnChecklistGet(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("checklist_get").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID uuid.UUID `json:"task_id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.TaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID is required", 400)
+	}
+
+	_, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	items, err := cfg.DB.GetChecklistItemsByTask(ctx, payload.Data.TaskID)
+	if err != nil {
+		logDBError("Failed to load checklist", err)
+		return sendError(c, ErrorDatabaseError, "Failed to load checklist", 500)
+	}
+
+	cfg.WSClientManager.SendToClient(ctx, "checklist_get", SID, struct {
+		TaskID uuid.UUID                    `json:"task_id"`
+		Items  []database.TaskChecklistItem `json:"items"`
+	}{
+		TaskID: payload.Data.TaskID,
+		Items:  items,
+	})
+	return nil
+}
+
+// taskNoteMaxLength caps a single note, longer text belongs in the description
+const taskNoteMaxLength = 10000
+
+// taskNoteSearchLimit caps the results of task_notes_search
+const taskNoteSearchLimit = 100
+
+// loadOwnedTaskNote is loadOwnedTask for task notes
+func (cfg *config) loadOwnedTaskNote(ctx context.Context, c *websocket.Conn, userID, noteID uuid.UUID) (note database.TaskNote, ok bool, err error) {
+	note, err = cfg.DB.GetTaskNoteByID(ctx, noteID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return note, false, sendError(c, "not_found", "Note not found", 404)
+		}
+		logDBError("Failed to load note "+noteID.String(), err)
+		return note, false, sendError(c, ErrorDatabaseError, "Failed to load note", 500)
+	}
+	if note.UserID != userID {
+		return note, false, sendError(c, "unauthorized", "Note does not belong to user", 403)
+	}
+	return note, true, nil
+}
+
+func (cfg *config) WSOnTaskNoteAdd(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_note_add").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID     uuid.UUID `json:"id"`
+			TaskID uuid.UUID `json:"task_id"`
+			Body   string    `json:"body"`
+			Device string    `json:"device"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	body := strings.TrimSpace(payload.Data.Body)
+	if payload.Data.TaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID is required", 400)
+	}
+	if body == "" {
+		return sendError(c, "invalid_request", "Note body is required", 400)
+	}
+	if len(body) > taskNoteMaxLength {
+		return sendError(c, "invalid_request", fmt.Sprintf("Note is longer than %d characters", taskNoteMaxLength), 400)
+	}
+
+	_, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	// Clients may pick the ID so they can show the note before the echo arrives
+	id := payload.Data.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	device := strings.TrimSpace(payload.Data.Device)
+	note, err := cfg.DB.CreateTaskNote(ctx, database.CreateTaskNoteParams{
+		ID:        id,
+		TaskID:    payload.Data.TaskID,
+		UserID:    client.User.ID,
+		Body:      body,
+		DeviceSid: uuid.NullUUID{UUID: SID, Valid: true},
+		Device:    sql.NullString{String: device, Valid: device != ""},
+	})
+	if err != nil {
+		logDBError("Failed to create note", err)
+		return sendError(c, ErrorDatabaseError, "Failed to add note", 500)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "task_note_added", client.User.ID, note)
+	return nil
+}
+
+func (cfg *config) WSOnTaskNoteEdit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_note_edit").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID   uuid.UUID `json:"id"`
+			Body string    `json:"body"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	body := strings.TrimSpace(payload.Data.Body)
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Note ID is required", 400)
+	}
+	if body == "" {
+		return sendError(c, "invalid_request", "Note body is required", 400)
+	}
+	if len(body) > taskNoteMaxLength {
+		return sendError(c, "invalid_request", fmt.Sprintf("Note is longer than %d characters", taskNoteMaxLength), 400)
+	}
+
+	_, ok, err := cfg.loadOwnedTaskNote(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+
+	note, err := cfg.DB.UpdateTaskNoteBody(ctx, database.UpdateTaskNoteBodyParams{
+		ID:   payload.Data.ID,
+		Body: body,
+	})
+	if err != nil {
+		logDBError("Failed to edit note", err)
+		return sendError(c, ErrorDatabaseError, "Failed to edit note", 500)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "task_note_edited", client.User.ID, note)
+	return nil
+}
+
+func (cfg *config) WSOnTaskNoteDelete(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_note_delete").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_request", "Note ID is required", 400)
+	}
+
+	note, ok, err := cfg.loadOwnedTaskNote(ctx, c, client.User.ID, payload.Data.ID)
+	if !ok {
+		return err
+	}
+
+	if err := cfg.DB.DeleteTaskNote(ctx, note.ID); err != nil {
+		logDBError("Failed to delete note", err)
+		return sendError(c, ErrorDatabaseError, "Failed to delete note", 500)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "task_note_deleted", client.User.ID, struct {
+		ID     uuid.UUID `json:"id"`
+		TaskID uuid.UUID `json:"task_id"`
+	}{
+		ID:     note.ID,
+		TaskID: note.TaskID,
+	})
+	return nil
+}
+
+func (cfg *config) WSOnTaskNotesGet(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_notes_get").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID uuid.UUID `json:"task_id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.TaskID == uuid.Nil {
+		return sendError(c, "invalid_request", "Task ID is required", 400)
+	}
+
+	_, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+
+	notes, err := cfg.DB.GetTaskNotesByTask(ctx, payload.Data.TaskID)
+	if err != nil {
+		logDBError("Failed to load notes", err)
+		return sendError(c, ErrorDatabaseError, "Failed to load notes", 500)
+	}
+
+	cfg.WSClientManager.SendToClient(ctx, "task_notes_get", SID, struct {
+		TaskID uuid.UUID           `json:"task_id"`
+		Notes  []database.TaskNote `json:"notes"`
+	}{
+		TaskID: payload.Data.TaskID,
+		Notes:  notes,
+	})
+	return nil
+}
+
+func (cfg *config) WSOnTaskNotesSearch(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_notes_search").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			Query string `json:"query"`
+			Limit int32  `json:"limit"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	query := strings.TrimSpace(payload.Data.Query)
+	if query == "" {
+		return sendError(c, "invalid_request", "Search query is required", 400)
+	}
+
+	limit := payload.Data.Limit
+	if limit <= 0 || limit > taskNoteSearchLimit {
+		limit = taskNoteSearchLimit
+	}
+
+	notes, err := cfg.DB.SearchTaskNotes(ctx, database.SearchTaskNotesParams{
+		UserID:      client.User.ID,
+		SearchQuery: "%" + query + "%",
+		LimitVal:    limit,
+	})
+	if err != nil {
+		logDBError("Failed to search notes", err)
+		return sendError(c, ErrorDatabaseError, "Failed to search notes", 500)
+	}
+
+	cfg.WSClientManager.SendToClient(ctx, "task_notes_search", SID, struct {
+		Query string                        `json:"query"`
+		Notes []database.SearchTaskNotesRow `json:"notes"`
+	}{
+		Query: query,
+		Notes: notes,
+	})
+	return nil
+}
+
+func (cfg *config) WSOnNotificationsFetch(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("notifications_fetch").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	type fetchRequest struct {
+		Offset            int32    `json:"offset"`
+		Limit             int32    `json:"limit"`
+		Statuses          []string `json:"statuses"`
+		NotificationTypes []string `json:"notification_types"`
+		Priorities        []string `json:"priorities"`
+		IncludeSnoozed    *bool    `json:"include_snoozed"`
+		ExpiredOnly       *bool    `json:"expired_only"`
+	}
+
+	var payload struct {
+		Data fetchRequest `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	const defaultPageSize int32 = 10
+	const maxPageSize int32 = 100
+
+	offset := payload.Data.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	limit := payload.Data.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	params := database.ListNotificationsByUserParams{
+		UserID:            client.User.ID,
+		Statuses:          payload.Data.Statuses,
+		NotificationTypes: payload.Data.NotificationTypes,
+		Priorities:        payload.Data.Priorities,
+		OffsetVal:         sql.NullInt32{Int32: offset, Valid: true},
+		LimitVal:          sql.NullInt32{Int32: limit, Valid: true},
+	}
+
+	if payload.Data.IncludeSnoozed != nil {
+		params.IncludeSnoozed = sql.NullBool{Bool: *payload.Data.IncludeSnoozed, Valid: true}
+	}
+	if payload.Data.ExpiredOnly != nil {
+		params.ExpiredOnly = sql.NullBool{Bool: *payload.Data.ExpiredOnly, Valid: true}
+	}
+
+	notifications, err := cfg.DB.ListNotificationsByUserWithTiming(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	response := struct {
+		Notifications []database.Notification `json:"notifications"`
+		Offset        int32                   `json:"offset"`
+		Limit         int32                   `json:"limit"`
+		HasMore       bool                    `json:"has_more"`
+	}{
+		Notifications: notifications,
+		Offset:        offset,
+		Limit:         limit,
+		HasMore:       int32(len(notifications)) == limit,
+	}
+
+	return cfg.WSClientManager.SendToClient(ctx, "notifications_batch", SID, response)
+}
+
+func (cfg *config) WSOnNotificationMarkSeen(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("notification_mark_seen").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			NotificationIDs []uuid.UUID `json:"notification_ids"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if len(payload.Data.NotificationIDs) == 0 {
+		return nil
+	}
+
+	lastModified := time.Now().UnixMilli()
+	updates, err := cfg.DB.MarkNotificationsSeenWithTiming(ctx, database.MarkNotificationsSeenParams{
+		LastModifiedAt:  lastModified,
+		UserID:          client.User.ID,
+		NotificationIds: payload.Data.NotificationIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	cfg.broadcastNotificationSet(ctx, "notifications_marked_seen", client.User.ID, updates)
+	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
+	return nil
+}
+
+func (cfg *config) WSOnNotificationMarkAllSeen(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("notification_mark_all_seen").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			NotificationIDs []uuid.UUID `json:"notification_ids"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	lastModified := time.Now().UnixMilli()
+	var (
+		updates []database.Notification
+		err     error
+	)
+
+	if len(payload.Data.NotificationIDs) > 0 {
+		updates, err = cfg.DB.MarkNotificationsSeenWithTiming(ctx, database.MarkNotificationsSeenParams{
+			LastModifiedAt:  lastModified,
+			UserID:          client.User.ID,
+			NotificationIds: payload.Data.NotificationIDs,
+		})
+	} else {
+		updates, err = cfg.DB.MarkAllNotificationsSeenWithTiming(ctx, database.MarkAllNotificationsSeenParams{
+			UserID:         client.User.ID,
+			LastModifiedAt: lastModified,
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cfg.broadcastNotificationSet(ctx, "notifications_marked_seen", client.User.ID, updates)
+	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
+	return nil
+}
+
+func (cfg *config) WSOnNotificationArchive(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("notification_archive").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			NotificationID uuid.UUID `json:"notification_id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.NotificationID == uuid.Nil {
+		return fmt.Errorf("notification_id is required")
+	}
+
+	lastModified := time.Now().UnixMilli()
+	notification, err := cfg.DB.ArchiveNotificationWithTiming(ctx, database.ArchiveNotificationParams{
+		ID:             payload.Data.NotificationID,
+		UserID:         client.User.ID,
+		LastModifiedAt: lastModified,
+	})
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg.broadcastSingleNotification(ctx, "notification_archived", client.User.ID, notification)
+	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
+	return nil
+}
+
+func (cfg *config) WSOnNotificationSnooze(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("notification_snooze").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			NotificationID uuid.UUID `json:"notification_id"`
+			SnoozeUntil    *int64    `json:"snooze_until"`   // epoch millis
+			SnoozeMinutes  *int64    `json:"snooze_minutes"` // minutes from now
+			SnoozeSeconds  *int64    `json:"snooze_seconds"` // seconds from now
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Data.NotificationID == uuid.Nil {
+		return fmt.Errorf("notification_id is required")
+	}
+
+	var snoozeUntil time.Time
+	now := time.Now()
+	switch {
+	case payload.Data.SnoozeUntil != nil:
+		snoozeUntil = time.UnixMilli(*payload.Data.SnoozeUntil)
+	case payload.Data.SnoozeMinutes != nil:
+		snoozeUntil = now.Add(time.Duration(*payload.Data.SnoozeMinutes) * time.Minute)
+	case payload.Data.SnoozeSeconds != nil:
+		snoozeUntil = now.Add(time.Duration(*payload.Data.SnoozeSeconds) * time.Second)
+	default:
+		snoozeUntil = now.Add(5 * time.Minute)
+	}
+
+	if snoozeUntil.Before(now.Add(5 * time.Second)) {
+		snoozeUntil = now.Add(5 * time.Minute)
+	}
+
+	lastModified := time.Now().UnixMilli()
+	notification, err := cfg.DB.SnoozeNotificationWithTiming(ctx, database.SnoozeNotificationParams{
+		ID:             payload.Data.NotificationID,
+		SnoozedUntil:   sql.NullTime{Time: snoozeUntil.UTC(), Valid: true},
+		UserID:         client.User.ID,
+		LastModifiedAt: lastModified,
+	})
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg.broadcastSingleNotification(ctx, "notification_snoozed", client.User.ID, notification)
+	cfg.emitNotificationUnseenCount(ctx, client.User.ID)
+	return nil
+}
+
+func (cfg *config) emitNotificationUnseenCount(ctx context.Context, userID uuid.UUID) {
+	count, err := cfg.DB.CountUnseenNotificationsWithTiming(ctx, userID)
+	if err != nil {
+		log.Println("failed to compute unseen notification count:", err)
+		return
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "notifications_unseen_count", userID, struct {
+		Count int64 `json:"count"`
+	}{
+		Count: count,
+	})
+}
+
+func (cfg *config) broadcastNotificationSet(ctx context.Context, event string, userID uuid.UUID, notifications []database.Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, event, userID, struct {
+		Notifications []database.Notification `json:"notifications"`
+	}{
+		Notifications: notifications,
+	})
+}
+
+func (cfg *config) broadcastSingleNotification(ctx context.Context, event string, userID uuid.UUID, notification database.Notification) {
+	cfg.WSClientManager.BroadcastToSameUser(ctx, event, userID, notification)
+}
+
+func (cfg *config) DispatchDueNotifications() {
+	log.Printf("DispatchDueNotifications cron job started at %s UTC", time.Now().UTC().Format(time.RFC3339))
+	ctx := context.Background()
+	lastModified := time.Now().UnixMilli()
+
+	// Handle snoozed notifications
+	notifications, err := cfg.DB.ReleaseDueSnoozedNotificationsWithTiming(ctx, lastModified)
+	if err != nil {
+		log.Println("failed to release snoozed notifications:", err)
+		return
+	}
+
+	if len(notifications) > 0 {
+		userBuckets := make(map[uuid.UUID][]database.Notification)
+		for _, notification := range notifications {
+			userBuckets[notification.UserID] = append(userBuckets[notification.UserID], notification)
+		}
+
+		for userID, bucket := range userBuckets {
+			cfg.broadcastNotificationSet(ctx, "notifications_reemitted", userID, bucket)
+			cfg.emitNotificationUnseenCount(ctx, userID)
+		}
+	}
+
+	cfg.dispatchTaskVisibility(ctx)
+	log.Printf("DispatchDueNotifications cron job completed at %s UTC", time.Now().UTC().Format(time.RFC3339))
+}
+
+func (cfg *config) dispatchTaskVisibility(ctx context.Context) {
+	log.Println("dispatchTaskVisibility: Starting task visibility check")
+	tasks, err := cfg.DB.GetTasksDueForVisibilityAllWithTiming(ctx)
+	if err != nil {
+		log.Printf("Failed to fetch tasks due for visibility: %v", err)
+		return
+	}
+
+	// Deferrals are cleared once they expire, so each task comes back exactly once
+	released, err := cfg.DB.ReleaseExpiredDeferrals(ctx, time.Now().UnixMilli())
+	if err != nil {
+		log.Printf("Failed to release expired deferrals: %v", err)
+	} else {
+		// A deferral can expire in the same minute the due window opens; the released
+		// copy is the current one, so it replaces the other instead of being sent twice
+		index := make(map[uuid.UUID]int, len(tasks))
+		for i, task := range tasks {
+			index[task.ID] = i
+		}
+		for _, task := range released {
+			if i, ok := index[task.ID]; ok {
+				tasks[i] = task
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+	}
+	log.Printf("dispatchTaskVisibility: Found %d tasks due for visibility", len(tasks))
+	if len(tasks) == 0 {
+		log.Println("dispatchTaskVisibility: No tasks found, returning")
+		return
+	}
+
+	buckets := make(map[uuid.UUID][]database.Task)
+	for _, task := range tasks {
+		buckets[task.UserID] = append(buckets[task.UserID], task)
+	}
+
+	for userID, bucket := range buckets {
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "tasks_became_visible", userID, struct {
+			Tasks []database.Task `json:"tasks"`
+		}{
+			Tasks: bucket,
+		})
+		log.Printf("Broadcasted %d tasks becoming visible for user %s", len(bucket), userID)
+	}
+}
+
+// defaultTaskDueOffsets are the minutes before due_at at which a task's due reminders
+// go out, the same as the notify_offsets_min default of task schedules
+var defaultTaskDueOffsets = []int32{2880, 1440, 720, 360, 180}
+
+// planTaskDueJobs cancels the task's pending due reminders and, while the task is open
+// with a due date, queues one notification job per offset that is still ahead. Jobs of
+// a schedule occurrence linked to the task are canceled too, so a schedule-created task
+// that gets a new due date follows the task rather than the schedule; the schedule's
+// own offsets still apply, see taskDueOffsets.
+// Failures are logged rather than returned, like task revisions.
+func planTaskDueJobs(ctx context.Context, queries *database.Queries, task database.Task) {
+	cancelTaskDueJobs(ctx, queries, task.ID)
+	if task.IsCompleted || !task.DueAt.Valid {
+		return
+	}
+
+	now := time.Now()
+	for _, off := range taskDueOffsets(ctx, queries, task.ID) {
+		sendAt := task.DueAt.Time.Add(-time.Duration(off) * time.Minute)
+		if sendAt.Before(now) {
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"task_id":        task.ID.String(),
+			"offset_minutes": off,
+			"title":          task.Title,
+			"kind":           "task",
+			"due_at":         task.DueAt.Time,
+		}
+		payloadJSON, _ := json.Marshal(payload)
+
+		err := queries.UpsertTaskNotificationJob(ctx, database.UpsertTaskNotificationJobParams{
+			UserID:        task.UserID,
+			TaskID:        uuid.NullUUID{UUID: task.ID, Valid: true},
+			OffsetMinutes: off,
+			PlannedSendAt: sendAt,
+			Payload:       payloadJSON,
+		})
+		if err != nil {
+			logDBError(fmt.Sprintf("Failed to plan %d minute due reminder for task %s", off, task.ID), err)
+			return
+		}
+	}
+}
+
+// taskDueOffsets returns the reminder offsets for a task. Tasks created by a schedule,
+// and their rollover clones, keep the schedule's notify_offsets_min minus its
+// muted_offsets_min; the exact-time offset 0 stays with the schedule's own reminders.
+// Every other task gets defaultTaskDueOffsets.
+func taskDueOffsets(ctx context.Context, queries *database.Queries, taskID uuid.UUID) []int32 {
+	schedule, err := queries.GetTaskScheduleOffsets(ctx, taskID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logDBError("Failed to load schedule reminder offsets for task "+taskID.String(), err)
+		}
+		return defaultTaskDueOffsets
+	}
+
+	muted := make(map[int32]bool, len(schedule.MutedOffsetsMin))
+	for _, off := range schedule.MutedOffsetsMin {
+		muted[off] = true
+	}
+	offsets := []int32{}
+	for _, off := range schedule.NotifyOffsetsMin {
+		if off != 0 && !muted[off] {
+			offsets = append(offsets, off)
+		}
+	}
+	return offsets
+}
+
+// dueRemindersChanged reports whether an edit invalidates the queued due reminders,
+// which carry the title, are timed off due_at and only go out for open tasks
+func dueRemindersChanged(before, after database.Task) bool {
+	if before.DueAt.Valid != after.DueAt.Valid || !before.DueAt.Time.Equal(after.DueAt.Time) {
+		return true
+	}
+	return before.Title != after.Title || before.IsCompleted != after.IsCompleted
+}
+
+// cancelTaskDueJobs drops the task's pending due reminders, for tasks that were
+// completed or are about to be deleted
+func cancelTaskDueJobs(ctx context.Context, queries *database.Queries, taskID uuid.UUID) {
+	err := queries.CancelPendingJobsForTask(ctx, uuid.NullUUID{UUID: taskID, Valid: true})
+	logDBError("Failed to cancel due reminders for task "+taskID.String(), err)
+}
+
+// Schedule Management WebSocket Event Handlers
+
+func (cfg *config) WSOnScheduleCreate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("schedule_create").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	type scheduleCreateRequest struct {
+		Kind              string     `json:"kind"`
+		Title             string     `json:"title"`
+		Tz                string     `json:"tz"`
+		StartLocal        time.Time  `json:"start_local"`
+		Rrule             *string    `json:"rrule"`
+		UntilLocal        *time.Time `json:"until_local"`
+		ShowBeforeMinutes *int32     `json:"show_before_minutes"`
+		NotifyOffsetsMin  []int32    `json:"notify_offsets_min"`
+		MutedOffsetsMin   []int32    `json:"muted_offsets_min"`
+		Category          *string    `json:"category"`
+		EstimateMinutes   *int32     `json:"estimate_minutes"`
+	}
+
+	var payload struct {
+		Data scheduleCreateRequest `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return sendError(c, "invalid_data", "Invalid schedule data", 400)
+	}
+
+	// Validate required fields
+	if payload.Data.Kind == "" || payload.Data.Title == "" || payload.Data.Tz == "" {
+		return sendError(c, "invalid_data", "Kind, title, and timezone are required", 400)
+	}
+
+	// Validate kind
+	if payload.Data.Kind != "task" && payload.Data.Kind != "reminder" {
+		return sendError(c, "invalid_data", "Kind must be 'task' or 'reminder'", 400)
+	}
+
+	// Prepare parameters
+	var rrule sql.NullString
+	if payload.Data.Rrule != nil {
+		rrule = sql.NullString{String: *payload.Data.Rrule, Valid: true}
+	}
+
+	var untilLocal sql.NullTime
+	if payload.Data.UntilLocal != nil {
+		untilLocal = sql.NullTime{Time: *payload.Data.UntilLocal, Valid: true}
+	}
+
+	var showBeforeMinutes sql.NullInt32
+	if payload.Data.ShowBeforeMinutes != nil {
+		showBeforeMinutes = sql.NullInt32{Int32: *payload.Data.ShowBeforeMinutes, Valid: true}
+	}
+
+	// Create schedule
+	schedule, err := cfg.DB.CreateSchedule(ctx, database.CreateScheduleParams{
+		UserID:          client.User.ID,
+		Kind:            payload.Data.Kind,
+		Title:           payload.Data.Title,
+		Tz:              payload.Data.Tz,
+		StartLocal:      payload.Data.StartLocal,
+		Rrule:           rrule,
+		UntilLocal:      untilLocal,
+		Column8:         showBeforeMinutes,
+		Column9:         payload.Data.NotifyOffsetsMin,
+		Column10:        payload.Data.MutedOffsetsMin,
+		Column11:        payload.Data.Category,
+		EstimateMinutes: nullableEstimate(payload.Data.EstimateMinutes),
+	})
+	if err != nil {
+		log.Printf("Failed to create schedule: %v", err)
+		return sendError(c, ErrorDatabaseError, "Failed to create schedule", 500)
+	}
+
+	// Send success response
+	cfg.WSClientManager.SendToClient(ctx, "schedule_created", SID, schedule)
+	return nil
+}
+
+func (cfg *config) WSOnScheduleEdit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("schedule_edit").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	type scheduleEditRequest struct {
+		ID                uuid.UUID  `json:"id"`
+		Title             string     `json:"title"`
+		Tz                string     `json:"tz"`
+		StartLocal        time.Time  `json:"start_local"`
+		Rrule             *string    `json:"rrule"`
+		UntilLocal        *time.Time `json:"until_local"`
+		ShowBeforeMinutes *int32     `json:"show_before_minutes"`
+		NotifyOffsetsMin  []int32    `json:"notify_offsets_min"`
+		MutedOffsetsMin   []int32    `json:"muted_offsets_min"`
+		Category          *string    `json:"category"`
+		EstimateMinutes   *int32     `json:"estimate_minutes"`
+	}
+
+	var payload struct {
+		Data scheduleEditRequest `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return sendError(c, "invalid_data", "Invalid schedule data", 400)
+	}
+
+	// Validate schedule ID
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_data", "Schedule ID is required", 400)
+	}
+
+	// Check if schedule exists and belongs to user
+	existingSchedule, err := cfg.DB.GetScheduleByID(ctx, payload.Data.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Schedule not found", 404)
+		}
+		return sendError(c, ErrorDatabaseError, "Database error", 500)
+	}
+
+	if existingSchedule.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Schedule does not belong to user", 403)
+	}
+
+	// Cancel future jobs and delete future occurrences
+	if err := cfg.DB.CancelFutureJobsForSchedule(ctx, uuid.NullUUID{UUID: payload.Data.ID, Valid: true}); err != nil {
+		log.Printf("Failed to cancel future jobs: %v", err)
+	}
+
+	if err := cfg.DB.DeleteFutureOccurrencesForSchedule(ctx, payload.Data.ID); err != nil {
+		log.Printf("Failed to delete future occurrences: %v", err)
+	}
+
+	// Increment revision
+	if err := cfg.DB.IncrementScheduleRev(ctx, payload.Data.ID); err != nil {
+		log.Printf("Failed to increment schedule revision: %v", err)
+	}
+
+	// Prepare category
+	var category sql.NullString
+	if payload.Data.Category != nil {
+		category = sql.NullString{String: *payload.Data.Category, Valid: true}
+	}
+
+	// Update schedule
+	schedule, err := cfg.DB.UpdateSchedule(ctx, database.UpdateScheduleParams{
+		ID:                payload.Data.ID,
+		Title:             payload.Data.Title,
+		Tz:                payload.Data.Tz,
+		StartLocal:        payload.Data.StartLocal,
+		Rrule:             sql.NullString{String: *payload.Data.Rrule, Valid: payload.Data.Rrule != nil},
+		UntilLocal:        sql.NullTime{Time: *payload.Data.UntilLocal, Valid: payload.Data.UntilLocal != nil},
+		ShowBeforeMinutes: sql.NullInt32{Int32: *payload.Data.ShowBeforeMinutes, Valid: payload.Data.ShowBeforeMinutes != nil},
+		NotifyOffsetsMin:  payload.Data.NotifyOffsetsMin,
+		MutedOffsetsMin:   payload.Data.MutedOffsetsMin,
+		Category:          category,
+		EstimateMinutes:   nullableEstimate(payload.Data.EstimateMinutes),
+	})
+	if err != nil {
+		log.Printf("Failed to update schedule: %v", err)
+		return sendError(c, ErrorDatabaseError, "Failed to update schedule", 500)
+	}
+
+	// Send success response
+	cfg.WSClientManager.SendToClient(ctx, "schedule_updated", SID, schedule)
+	return nil
+}
+
+func (cfg *config) WSOnScheduleDelete(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("schedule_delete").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	type scheduleDeleteRequest struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	var payload struct {
+		Data scheduleDeleteRequest `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return sendError(c, "invalid_data", "Invalid schedule data", 400)
+	}
+
+	// Validate schedule ID
+	if payload.Data.ID == uuid.Nil {
+		return sendError(c, "invalid_data", "Schedule ID is required", 400)
+	}
+
+	// Check if schedule exists and belongs to user
+	existingSchedule, err := cfg.DB.GetScheduleByID(ctx, payload.Data.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Schedule not found", 404)
+		}
+		return sendError(c, ErrorDatabaseError, "Database error", 500)
+	}
+
+	if existingSchedule.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Schedule does not belong to user", 403)
+	}
+
+	// Cancel future jobs
+	if err := cfg.DB.CancelFutureJobsForSchedule(ctx, uuid.NullUUID{UUID: payload.Data.ID, Valid: true}); err != nil {
+		log.Printf("Failed to cancel future jobs: %v", err)
+	}
+
+	// Delete future occurrences (this will cascade to notification jobs and task_links)
+	if err := cfg.DB.DeleteFutureOccurrencesForSchedule(ctx, payload.Data.ID); err != nil {
+		log.Printf("Failed to delete future occurrences: %v", err)
+	}
+
+	// Actually delete the schedule (this will cascade to all occurrences, notification jobs, and task_links)
+	if err := cfg.DB.DeleteSchedule(ctx, payload.Data.ID); err != nil {
+		log.Printf("Failed to delete schedule: %v", err)
+		return sendError(c, ErrorDatabaseError, "Failed to delete schedule", 500)
+	}
+
+	// Send success response
+	cfg.WSClientManager.SendToClient(ctx, "schedule_deleted", SID, struct {
+		ID uuid.UUID `json:"id"`
+	}{
+		ID: payload.Data.ID,
+	})
+	return nil
+}
+
+func (cfg *config) WSOnScheduleList(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("schedule_list").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	// Get user's schedules
+	schedules, err := cfg.DB.GetSchedulesByUser(ctx, client.User.ID)
+	if err != nil {
+		log.Printf("Failed to get schedules for user: %v", err)
+		return sendError(c, ErrorDatabaseError, "Failed to get schedules", 500)
+	}
+
+	// Send schedules list
+	cfg.WSClientManager.SendToClient(ctx, "schedules_list", SID, struct {
+		Schedules []database.Schedule `json:"schedules"`
+	}{
+		Schedules: schedules,
+	})
+	return nil
+}
+
+func (cfg *config) WSOnReminderSubmit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	// Get client
+	client, exists := cfg.WSClientManager.clients[SID]
+	if !exists {
+		return fmt.Errorf("client not found")
+	}
+
+	// Parse payload
+	var payload struct {
+		Event string `json:"event"`
+		Data  struct {
+			Title         string `json:"title"`
+			Kind          string `json:"kind"`
+			ScheduleInput string `json:"scheduleInput"`
+			Schedule      struct {
+				Instant *struct {
+					Label string `json:"label"`
+					ISO   string `json:"iso"`
+				} `json:"instant"`
+				Recurrence *struct {
+					Text  string   `json:"text"`
+					Rule  string   `json:"rule"`
+					Start string   `json:"start"`
+					Next  []string `json:"next"`
+				} `json:"recurrence"`
+			} `json:"schedule"`
+			ShowBeforeMinutes *int32  `json:"show_before_minutes"`
+			Category          *string `json:"category"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v", err)
+	}
+
+	// Validate required fields
+	if payload.Data.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if payload.Data.Kind != "task" && payload.Data.Kind != "reminder" {
+		return fmt.Errorf("kind must be 'task' or 'reminder'")
+	}
+	if payload.Data.Schedule.Instant == nil && payload.Data.Schedule.Recurrence == nil {
+		return fmt.Errorf("either instant or recurrence must be provided")
+	}
+	if payload.Data.ShowBeforeMinutes == nil {
+		return fmt.Errorf("show_before_minutes is required")
+	}
+
+	// Schedules follow the user's configured timezone; start_local stores that zone's wall time
+	loc := userLocation(client.User)
+	tz := loc.String()
+
+	var startLocal time.Time
+	var rrule sql.NullString
+	var untilLocal sql.NullTime
+
+	if payload.Data.Schedule.Instant != nil {
+		// One-off schedule
+		startTime, err := time.Parse(time.RFC3339, payload.Data.Schedule.Instant.ISO)
+		if err != nil {
+			return fmt.Errorf("invalid instant ISO format: %v", err)
+		}
+		startLocal = startTime.In(loc)
+		// rrule remains NULL for one-off
+	} else if payload.Data.Schedule.Recurrence != nil {
+		// Recurring schedule
+		startTime, err := time.Parse(time.RFC3339, payload.Data.Schedule.Recurrence.Start)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence start format: %v", err)
+		}
+		startLocal = startTime.In(loc)
+		rrule = sql.NullString{String: payload.Data.Schedule.Recurrence.Rule, Valid: true}
+		// untilLocal remains NULL (no end date specified)
+	}
+
+	// Create schedule
+	schedule, err := cfg.DB.CreateSchedule(ctx, database.CreateScheduleParams{
+		UserID:     client.User.ID,
+		Kind:       payload.Data.Kind,
+		Title:      payload.Data.Title,
+		Tz:         tz,
+		StartLocal: startLocal,
+		Rrule:      rrule,
+		UntilLocal: untilLocal,
+		Column8:    payload.Data.ShowBeforeMinutes, // show_before_minutes
+		Column9:    nil,                            // notify_offsets_min - will use default '{2880,1440,720,360,180}'
+		Column10:   nil,                            // muted_offsets_min - will use default '{}'
+		Column11:   payload.Data.Category,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create schedule: %v", err)
 	}
 
 	// For immediate reminders shown to the user right now, process them
@@ -2010,96 +4406,535 @@ func (cfg *config) WSOnReminderSubmit(ctx context.Context, c *websocket.Conn, SI
 		}
 	}
 
-	// Send success response
-	return cfg.WSClientManager.SendToClient(ctx, "reminder_submit_response", SID, struct {
-		Success  bool              `json:"success"`
-		Schedule database.Schedule `json:"schedule"`
-	}{
-		Success:  true,
-		Schedule: schedule,
-	})
+	// Send success response
+	return cfg.WSClientManager.SendToClient(ctx, "reminder_submit_response", SID, struct {
+		Success  bool              `json:"success"`
+		Schedule database.Schedule `json:"schedule"`
+	}{
+		Success:  true,
+		Schedule: schedule,
+	})
+}
+
+func (cfg *config) processImmediateReminder(ctx context.Context, schedule database.Schedule, occursAt time.Time) error {
+	occurrence, err := cfg.DB.UpsertOccurrence(ctx, database.UpsertOccurrenceParams{
+		ScheduleID: schedule.ID,
+		OccursAt:   occursAt,
+		Rev:        schedule.Rev,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create occurrence: %v", err)
+	}
+
+	// Create task if kind is 'task'
+	if schedule.Kind == "task" {
+		dueAt := calculateTaskDueDate(schedule, occursAt)
+
+		// Get category from schedule, default to "Life" if not set
+		category := "Life"
+		if schedule.Category.Valid {
+			category = schedule.Category.String
+		}
+
+		task, err := cfg.DB.CreateTask(ctx, database.CreateTaskParams{
+			ID:                uuid.New(),
+			Title:             schedule.Title,
+			Description:       "", // Could be enhanced later
+			CreatedAt:         occursAt,
+			CompletedAt:       sql.NullTime{Valid: false},
+			Duration:          "00:00:00",
+			Category:          category,
+			Tags:              []string{},
+			ToggledAt:         sql.NullInt64{Valid: false},
+			IsActive:          false,
+			IsCompleted:       false,
+			UserID:            schedule.UserID,
+			LastModifiedAt:    time.Now().UnixMilli(),
+			Priority:          sql.NullInt32{Valid: false},
+			DueAt:             dueAt,
+			ShowBeforeDueTime: schedule.ShowBeforeMinutes,
+			EstimateMinutes:   schedule.EstimateMinutes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create task: %v", err)
+		}
+
+		recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{}, "create", nil, task)
+
+		// Link task to occurrence
+		err = cfg.DB.LinkTaskToOccurrence(ctx, database.LinkTaskToOccurrenceParams{
+			OccurrenceID: occurrence.ID,
+			TaskID:       task.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link task to occurrence: %v", err)
+		}
+
+		// Broadcast task creation event to user's connected devices
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "new_task_created", schedule.UserID, task)
+		log.Printf("Broadcasted new_task_created event for task %s to user %s", task.ID, schedule.UserID)
+
+		log.Printf("Created immediate task for schedule %s at %v", schedule.ID, occursAt)
+	}
+
+	// Create notification job for immediate reminder (offset 0)
+	payload := map[string]interface{}{
+		"schedule_id":    schedule.ID.String(),
+		"occurrence_id":  occurrence.ID.String(),
+		"offset_minutes": 0,
+		"title":          schedule.Title,
+		"kind":           schedule.Kind,
+	}
+	payloadJSON, _ := json.Marshal(payload)
+
+	err = cfg.DB.UpsertNotificationJob(ctx, database.UpsertNotificationJobParams{
+		UserID:        schedule.UserID,
+		ScheduleID:    uuid.NullUUID{UUID: schedule.ID, Valid: true},
+		OccurrenceID:  uuid.NullUUID{UUID: occurrence.ID, Valid: true},
+		OffsetMinutes: 0,
+		PlannedSendAt: occursAt,
+		Payload:       payloadJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification job: %v", err)
+	}
+
+	log.Printf("Created immediate reminder notification job for schedule %s at %v", schedule.ID, occursAt)
+	return nil
+}
+
+func (cfg *config) WSOnFocusStart(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("focus_start").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID       uuid.UUID `json:"task_id"`
+			WorkMinutes  int32     `json:"work_minutes"`
+			BreakMinutes int32     `json:"break_minutes"`
+			Cycles       int32     `json:"cycles"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	// Classic pomodoro unless the client asks otherwise
+	if payload.Data.WorkMinutes == 0 {
+		payload.Data.WorkMinutes = 25
+	}
+	if payload.Data.BreakMinutes == 0 {
+		payload.Data.BreakMinutes = 5
+	}
+	if payload.Data.Cycles == 0 {
+		payload.Data.Cycles = 4
+	}
+	if payload.Data.WorkMinutes < 0 || payload.Data.BreakMinutes < 0 || payload.Data.Cycles < 0 {
+		return sendError(c, "invalid_request", "work_minutes, break_minutes and cycles must be positive", 400)
+	}
+
+	task, err := cfg.DB.GetTaskByIDWithTiming(ctx, payload.Data.TaskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Task not found", 404)
+		}
+		return err
+	}
+	if task.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Task does not belong to user", 403)
+	}
+	if task.IsCompleted {
+		return sendError(c, "invalid_request", "Cannot focus on a completed task", 400)
+	}
+
+	if _, err := cfg.DB.GetRunningFocusSessionByUser(ctx, client.User.ID); err == nil {
+		return sendError(c, "conflict", "A focus session is already running", 409)
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+
+	if _, err := cfg.FocusService.Start(ctx, task, payload.Data.WorkMinutes, payload.Data.BreakMinutes, payload.Data.Cycles); err != nil {
+		logDBError("Failed to start focus session for task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to start focus session", 500)
+	}
+
+	return nil
+}
+
+func (cfg *config) WSOnFocusStop(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("focus_stop").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	session, err := cfg.DB.GetRunningFocusSessionByUser(ctx, client.User.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "No focus session is running", 404)
+		}
+		return err
+	}
+
+	if _, err := cfg.FocusService.Stop(ctx, session); err != nil {
+		logDBError("Failed to stop focus session "+session.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to stop focus session", 500)
+	}
+
+	return nil
 }
 
-func (cfg *config) processImmediateReminder(ctx context.Context, schedule database.Schedule, occursAt time.Time) error {
-	occurrence, err := cfg.DB.UpsertOccurrence(ctx, database.UpsertOccurrenceParams{
-		ScheduleID: schedule.ID,
-		OccursAt:   occursAt,
-		Rev:        schedule.Rev,
-	})
+// WSOnActivity records a user activity heartbeat, used by idle detection
+func (cfg *config) WSOnActivity(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("activity").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	return cfg.DB.TouchUserActivity(ctx, client.User.ID)
+}
+
+func (cfg *config) WSOnIdleResolve(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("idle_resolve").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID     uuid.UUID `json:"id"`
+			Action string    `json:"action"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	var status string
+	switch payload.Data.Action {
+	case "keep":
+		status = "kept"
+	case "discard":
+		status = "discarded"
+	default:
+		return sendError(c, "invalid_request", "action must be 'keep' or 'discard'", 400)
+	}
+
+	period, err := cfg.DB.GetIdlePeriodByID(ctx, payload.Data.ID)
 	if err != nil {
-		return fmt.Errorf("failed to create occurrence: %v", err)
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Idle period not found", 404)
+		}
+		return err
+	}
+	if period.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Idle period does not belong to user", 403)
 	}
 
-	// Create task if kind is 'task'
-	if schedule.Kind == "task" {
-		dueAt := calculateTaskDueDate(schedule, occursAt)
+	period, err = cfg.DB.ResolveIdlePeriod(ctx, database.ResolveIdlePeriodParams{
+		ID:     period.ID,
+		Status: status,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "conflict", "Idle period was already resolved", 409)
+		}
+		return err
+	}
 
-		// Get category from schedule, default to "Life" if not set
-		category := "Life"
-		if schedule.Category.Valid {
-			category = schedule.Category.String
+	// Discarding keeps the task as it was paused; keeping adds the idle time back
+	if status == "kept" {
+		task, err := cfg.DB.GetTaskByIDWithTiming(ctx, period.TaskID)
+		if err != nil {
+			return err
 		}
 
-		task, err := cfg.DB.CreateTask(ctx, database.CreateTaskParams{
-			ID:                uuid.New(),
-			Title:             schedule.Title,
-			Description:       "", // Could be enhanced later
-			CreatedAt:         occursAt,
-			CompletedAt:       sql.NullTime{Valid: false},
-			Duration:          "00:00:00",
-			Category:          category,
-			Tags:              []string{},
-			ToggledAt:         sql.NullInt64{Valid: false},
-			IsActive:          false,
-			IsCompleted:       false,
-			UserID:            schedule.UserID,
-			LastModifiedAt:    time.Now().UnixMilli(),
-			Priority:          sql.NullInt32{Valid: false},
-			DueAt:             dueAt,
-			ShowBeforeDueTime: schedule.ShowBeforeMinutes,
-		})
+		durationMs, err := durationStrToInt(task.Duration)
 		if err != nil {
-			return fmt.Errorf("failed to create task: %v", err)
+			return err
+		}
+		duration, err := durationIntToStr((durationMs + period.IdleUntil.Sub(period.IdleFrom).Milliseconds()) / 1000)
+		if err != nil {
+			return err
 		}
 
-		// Link task to occurrence
-		err = cfg.DB.LinkTaskToOccurrence(ctx, database.LinkTaskToOccurrenceParams{
-			OccurrenceID: occurrence.ID,
-			TaskID:       task.ID,
+		updated, err := cfg.DB.ToggleTaskWithTiming(ctx, database.ToggleTaskParams{
+			ID:             task.ID,
+			IsActive:       task.IsActive,
+			ToggledAt:      task.ToggledAt,
+			Duration:       duration,
+			LastModifiedAt: time.Now().UnixMilli(),
 		})
 		if err != nil {
-			return fmt.Errorf("failed to link task to occurrence: %v", err)
+			return err
 		}
 
-		// Broadcast task creation event to user's connected devices
-		cfg.WSClientManager.BroadcastToSameUser(ctx, "new_task_created", schedule.UserID, task)
-		log.Printf("Broadcasted new_task_created event for task %s to user %s", task.ID, schedule.UserID)
+		recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "idle_keep", &task, updated)
 
-		log.Printf("Created immediate task for schedule %s at %v", schedule.ID, occursAt)
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, updated)
 	}
 
-	// Create notification job for immediate reminder (offset 0)
-	payload := map[string]interface{}{
-		"schedule_id":    schedule.ID.String(),
-		"occurrence_id":  occurrence.ID.String(),
-		"offset_minutes": 0,
-		"title":          schedule.Title,
-		"kind":           schedule.Kind,
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "idle_period_resolved", client.User.ID, period)
+
+	cfg.refreshGoalProgress(ctx, SID)
+	return nil
+}
+
+type goalT struct {
+	Scope         string `json:"scope"`
+	ScopeValue    string `json:"scope_value"`
+	Period        string `json:"period"`
+	TargetMinutes int32  `json:"target_minutes"`
+	Direction     string `json:"direction"`
+}
+
+// validateGoal returns a client facing message when the goal is invalid
+func validateGoal(goal goalT) string {
+	switch {
+	case goal.Scope != "category" && goal.Scope != "tag":
+		return "scope must be 'category' or 'tag'"
+	case strings.TrimSpace(goal.ScopeValue) == "":
+		return "scope_value is required"
+	case goal.Period != "day" && goal.Period != "week" && goal.Period != "month":
+		return "period must be 'day', 'week' or 'month'"
+	case goal.TargetMinutes <= 0:
+		return "target_minutes must be positive"
+	case goal.Direction != "at_least" && goal.Direction != "at_most":
+		return "direction must be 'at_least' or 'at_most'"
+	}
+	return ""
+}
+
+// refreshGoalProgress recomputes the goals of the user behind SID after their tracked time changed
+func (cfg *config) refreshGoalProgress(ctx context.Context, SID uuid.UUID) {
+	if cfg.GoalService == nil {
+		return
 	}
-	payloadJSON, _ := json.Marshal(payload)
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return
+	}
+	cfg.GoalService.Refresh(ctx, client.User)
+}
 
-	err = cfg.DB.UpsertNotificationJob(ctx, database.UpsertNotificationJobParams{
-		UserID:        schedule.UserID,
-		ScheduleID:    uuid.NullUUID{UUID: schedule.ID, Valid: true},
-		OccurrenceID:  occurrence.ID,
-		OffsetMinutes: 0,
-		PlannedSendAt: occursAt,
-		Payload:       payloadJSON,
+func (cfg *config) WSOnGoalCreate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("goal_create").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data goalT `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if message := validateGoal(payload.Data); message != "" {
+		return sendError(c, "invalid_request", message, 400)
+	}
+
+	_, err := cfg.DB.CreateGoal(ctx, database.CreateGoalParams{
+		ID:            uuid.New(),
+		UserID:        client.User.ID,
+		Scope:         payload.Data.Scope,
+		ScopeValue:    strings.TrimSpace(payload.Data.ScopeValue),
+		Period:        payload.Data.Period,
+		TargetMinutes: payload.Data.TargetMinutes,
+		Direction:     payload.Data.Direction,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create notification job: %v", err)
+		logDBError("Failed to create goal for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to create goal", 500)
 	}
 
-	log.Printf("Created immediate reminder notification job for schedule %s at %v", schedule.ID, occursAt)
+	cfg.GoalService.Refresh(ctx, client.User)
+	return nil
+}
+
+func (cfg *config) WSOnGoalEdit(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("goal_edit").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID uuid.UUID `json:"id"`
+			goalT
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if message := validateGoal(payload.Data.goalT); message != "" {
+		return sendError(c, "invalid_request", message, 400)
+	}
+
+	goal, err := cfg.DB.GetGoalByID(ctx, payload.Data.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Goal not found", 404)
+		}
+		return err
+	}
+	if goal.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Goal does not belong to user", 403)
+	}
+
+	_, err = cfg.DB.UpdateGoal(ctx, database.UpdateGoalParams{
+		ID:            goal.ID,
+		Scope:         payload.Data.Scope,
+		ScopeValue:    strings.TrimSpace(payload.Data.ScopeValue),
+		Period:        payload.Data.Period,
+		TargetMinutes: payload.Data.TargetMinutes,
+		Direction:     payload.Data.Direction,
+	})
+	if err != nil {
+		logDBError("Failed to update goal "+goal.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to update goal", 500)
+	}
+
+	cfg.GoalService.Refresh(ctx, client.User)
+	return nil
+}
+
+func (cfg *config) WSOnGoalDelete(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("goal_delete").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	goal, err := cfg.DB.GetGoalByID(ctx, payload.Data.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return sendError(c, "not_found", "Goal not found", 404)
+		}
+		return err
+	}
+	if goal.UserID != client.User.ID {
+		return sendError(c, "unauthorized", "Goal does not belong to user", 403)
+	}
+
+	if err := cfg.DB.DeleteGoal(ctx, goal.ID); err != nil {
+		logDBError("Failed to delete goal "+goal.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to delete goal", 500)
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "goal_deleted", client.User.ID, struct {
+		ID uuid.UUID `json:"id"`
+	}{
+		ID: goal.ID,
+	})
 	return nil
 }
+
+func (cfg *config) WSOnGoalsList(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("goals_list").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	progress, err := cfg.GoalService.Progress(ctx, client.User)
+	if err != nil {
+		logDBError("Failed to load goals for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load goals", 500)
+	}
+
+	return cfg.WSClientManager.SendToClient(ctx, "goals_list", SID, struct {
+		Goals []goalProgress `json:"goals"`
+	}{
+		Goals: progress,
+	})
+}
+
+func (cfg *config) WSOnAchievementsList(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("achievements_list").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	achievements, err := cfg.DB.GetAchievementsByUser(ctx, client.User.ID)
+	if err != nil {
+		logDBError("Failed to load achievements for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load achievements", 500)
+	}
+	if achievements == nil {
+		achievements = []database.Achievement{}
+	}
+
+	streak, err := cfg.AchievementService.CurrentStreak(ctx, client.User)
+	if err != nil {
+		logDBError("Failed to compute streak for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load achievements", 500)
+	}
+
+	return cfg.WSClientManager.SendToClient(ctx, "achievements_list", SID, struct {
+		Achievements      []database.Achievement `json:"achievements"`
+		CurrentStreakDays int                    `json:"current_streak_days"`
+	}{
+		Achievements:      achievements,
+		CurrentStreakDays: streak,
+	})
+}
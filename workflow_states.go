@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/dinopy/taskbar2_server/internal/database"
+	"github.com/dinopy/taskbar2_server/internal/metrics"
+	"github.com/google/uuid"
+)
+
+const (
+	maxWorkflowStates       = 20
+	maxWorkflowStateNameLen = 50
+)
+
+// workflowStateKinds are what moving a task into a state does:
+// todo pauses a running timer, doing starts it, done completes the task
+var workflowStateKinds = map[string]struct{}{
+	"todo":  {},
+	"doing": {},
+	"done":  {},
+}
+
+type workflowStateT struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Kind string    `json:"kind"`
+}
+
+func validateWorkflowStates(states []workflowStateT, existing map[uuid.UUID]database.WorkflowState) string {
+	if len(states) > maxWorkflowStates {
+		return fmt.Sprintf("At most %d workflow states are allowed", maxWorkflowStates)
+	}
+
+	names := make(map[string]struct{}, len(states))
+	ids := make(map[uuid.UUID]struct{}, len(states))
+	for _, state := range states {
+		name := strings.TrimSpace(state.Name)
+		switch {
+		case name == "":
+			return "Workflow state name is required"
+		case len(name) > maxWorkflowStateNameLen:
+			return fmt.Sprintf("Workflow state names are limited to %d characters", maxWorkflowStateNameLen)
+		}
+		if _, ok := names[strings.ToLower(name)]; ok {
+			return fmt.Sprintf("Duplicate workflow state name %q", name)
+		}
+		names[strings.ToLower(name)] = struct{}{}
+
+		if _, ok := workflowStateKinds[state.Kind]; !ok && state.Kind != "" {
+			return "kind must be 'todo', 'doing' or 'done'"
+		}
+
+		if state.ID != uuid.Nil {
+			if _, ok := existing[state.ID]; !ok {
+				return "Unknown workflow state " + state.ID.String()
+			}
+			if _, ok := ids[state.ID]; ok {
+				return "Duplicate workflow state " + state.ID.String()
+			}
+			ids[state.ID] = struct{}{}
+		}
+	}
+	return ""
+}
+
+// WSOnWorkflowStatesUpdate replaces the user's ordered list of workflow states.
+// States left out of the list are deleted and their tasks lose their state.
+func (cfg *config) WSOnWorkflowStatesUpdate(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("workflow_states_update").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			States []workflowStateT `json:"states"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	current, err := cfg.DB.GetWorkflowStatesByUser(ctx, client.User.ID)
+	if err != nil {
+		logDBError("Failed to load workflow states for user "+client.User.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to load workflow states", 500)
+	}
+	existing := make(map[uuid.UUID]database.WorkflowState, len(current))
+	for _, state := range current {
+		existing[state.ID] = state
+	}
+
+	if message := validateWorkflowStates(payload.Data.States, existing); message != "" {
+		return sendError(c, "invalid_request", message, 400)
+	}
+
+	tx, err := cfg.DBPool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := cfg.DB.WithTx(tx)
+
+	states := make([]database.WorkflowState, 0, len(payload.Data.States))
+	for i, state := range payload.Data.States {
+		kind := state.Kind
+		if kind == "" {
+			kind = "todo"
+		}
+
+		var saved database.WorkflowState
+		if state.ID != uuid.Nil {
+			saved, err = queries.UpdateWorkflowState(ctx, database.UpdateWorkflowStateParams{
+				ID:       state.ID,
+				Name:     strings.TrimSpace(state.Name),
+				Kind:     kind,
+				Position: int32(i),
+			})
+			delete(existing, state.ID)
+		} else {
+			saved, err = queries.CreateWorkflowState(ctx, database.CreateWorkflowStateParams{
+				ID:       uuid.New(),
+				UserID:   client.User.ID,
+				Name:     strings.TrimSpace(state.Name),
+				Kind:     kind,
+				Position: int32(i),
+			})
+		}
+		if err != nil {
+			logDBError("Failed to save workflow state for user "+client.User.ID.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to save workflow states", 500)
+		}
+		states = append(states, saved)
+	}
+
+	// Whatever is left in existing was dropped from the list
+	lastEpochMs := time.Now().UnixMilli()
+	var releasedTasks []database.Task
+	for id := range existing {
+		released, err := queries.ClearTasksState(ctx, database.ClearTasksStateParams{
+			StateID:        uuid.NullUUID{UUID: id, Valid: true},
+			LastModifiedAt: lastEpochMs,
+		})
+		if err != nil {
+			logDBError("Failed to clear workflow state "+id.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to save workflow states", 500)
+		}
+		releasedTasks = append(releasedTasks, released...)
+
+		if err := queries.DeleteWorkflowState(ctx, id); err != nil {
+			logDBError("Failed to delete workflow state "+id.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to save workflow states", 500)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	cfg.WSClientManager.BroadcastToSameUser(ctx, "workflow_states_updated", client.User.ID, struct {
+		States []database.WorkflowState `json:"states"`
+	}{
+		States: states,
+	})
+
+	for _, task := range releasedTasks {
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	}
+
+	return nil
+}
+
+// WSOnTaskMoveState puts a task in a workflow state and applies the state's kind
+func (cfg *config) WSOnTaskMoveState(ctx context.Context, c *websocket.Conn, SID uuid.UUID, data []byte) error {
+	start := time.Now()
+	defer func() {
+		metrics.WebSocketEventDuration.WithLabelValues("task_move_state").Observe(time.Since(start).Seconds())
+	}()
+
+	client, ok := cfg.getClientBySID(SID)
+	if !ok {
+		return fmt.Errorf("client not found for SID %s", SID)
+	}
+
+	var payload struct {
+		Data struct {
+			TaskID  uuid.UUID `json:"task_id"`
+			StateID uuid.UUID `json:"state_id"`
+			Timer   *bool     `json:"timer"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	task, ok, err := cfg.loadOwnedTask(ctx, c, client.User.ID, payload.Data.TaskID)
+	if !ok {
+		return err
+	}
+	if task.IsCompleted {
+		return sendError(c, "invalid_request", "Completed tasks cannot change state", 400)
+	}
+
+	// Without a state the task only leaves its column
+	kind := ""
+	stateID := uuid.NullUUID{}
+	if payload.Data.StateID != uuid.Nil {
+		state, err := cfg.DB.GetWorkflowStateByID(ctx, payload.Data.StateID)
+		if err != nil && err != sql.ErrNoRows {
+			logDBError("Failed to load workflow state "+payload.Data.StateID.String(), err)
+			return sendError(c, ErrorDatabaseError, "Failed to load workflow state", 500)
+		}
+		if err == sql.ErrNoRows || state.UserID != client.User.ID {
+			return sendError(c, "not_found", "Workflow state not found", 404)
+		}
+		kind = state.Kind
+		stateID = uuid.NullUUID{UUID: state.ID, Valid: true}
+	}
+	timer := payload.Data.Timer == nil || *payload.Data.Timer
+
+	now := time.Now()
+	lastEpochMs := now.UnixMilli()
+	previousTask := task
+
+	task, err = cfg.DB.SetTaskState(ctx, database.SetTaskStateParams{
+		ID:             task.ID,
+		StateID:        stateID,
+		LastModifiedAt: lastEpochMs,
+	})
+	if err != nil {
+		logDBError("Failed to set state of task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to move task", 500)
+	}
+
+	// The running segment is folded into the duration whenever the timer stops
+	trackedSeconds, err := taskTrackedSeconds(task, lastEpochMs)
+	if err != nil {
+		return err
+	}
+	duration, err := durationIntToStr(trackedSeconds)
+	if err != nil {
+		return err
+	}
+
+	completed := false
+	switch {
+	case kind == "done":
+		task, err = cfg.DB.CompleteTaskWithTiming(ctx, database.CompleteTaskParams{
+			ID:             task.ID,
+			Duration:       duration,
+			CompletedAt:    sql.NullTime{Time: now.UTC(), Valid: true},
+			LastModifiedAt: lastEpochMs,
+		})
+		completed = true
+	case kind == "doing" && timer && !task.IsActive:
+		task, err = cfg.DB.ToggleTaskWithTiming(ctx, database.ToggleTaskParams{
+			ID:             task.ID,
+			ToggledAt:      sql.NullInt64{Int64: lastEpochMs, Valid: true},
+			IsActive:       true,
+			Duration:       task.Duration,
+			LastModifiedAt: lastEpochMs,
+		})
+	case kind == "todo" && timer && task.IsActive:
+		task, err = cfg.DB.ToggleTaskWithTiming(ctx, database.ToggleTaskParams{
+			ID:             task.ID,
+			ToggledAt:      sql.NullInt64{Int64: lastEpochMs, Valid: true},
+			IsActive:       false,
+			Duration:       duration,
+			LastModifiedAt: lastEpochMs,
+		})
+	}
+	if err != nil {
+		logDBError("Failed to apply state to task "+task.ID.String(), err)
+		return sendError(c, ErrorDatabaseError, "Failed to move task", 500)
+	}
+
+	recordTaskRevision(ctx, cfg.DB, uuid.NullUUID{UUID: SID, Valid: true}, "move_state", &previousTask, task)
+
+	if completed {
+		cancelTaskDueJobs(ctx, cfg.DB, task.ID)
+		cfg.releaseDependentTasks(ctx, task.ID)
+
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_deleted", client.User.ID, struct {
+			ID uuid.UUID `json:"id"`
+		}{
+			ID: task.ID,
+		})
+	} else {
+		cfg.WSClientManager.BroadcastToSameUser(ctx, "related_task_edited", client.User.ID, task)
+	}
+
+	if task.IsActive != previousTask.IsActive || completed {
+		cfg.refreshGoalProgress(ctx, SID)
+	}
+	return nil
+}